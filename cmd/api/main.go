@@ -2,12 +2,20 @@ package main
 
 import (
 	"log"
+	"net"
 	"net/http"
+	"time"
+	"tts-api/internal/cache"
 	"tts-api/internal/config"
+	"tts-api/internal/grpcapi"
 	"tts-api/internal/handlers"
+	"tts-api/internal/jobs"
 	"tts-api/internal/middleware"
+	"tts-api/internal/prompts"
 	"tts-api/internal/voice"
 	"tts-api/internal/voice/downloader"
+	"tts-api/internal/voice/installer"
+	"tts-api/internal/wyoming"
 
 	_ "tts-api/docs" // Importa o pacote docs gerado pelo swag
 
@@ -30,9 +38,13 @@ import (
 // @name Authorization
 func main() {
 	cfg := config.Load()
+	if cfg.SignedURLSecretDerived {
+		log.Printf("Aviso: SIGNED_URL_SECRET não definido; as URLs assinadas usam uma chave derivada do AUTH_TOKEN e deixam de valer quando o token muda")
+	}
 
 	// Download das vozes solicitadas
-	if err := downloader.DownloadVoices(cfg.VoicesDir, cfg.Voices); err != nil {
+	voiceSource := downloader.Source{ManifestURL: cfg.VoicesManifestURL, BaseURL: cfg.VoicesBaseURL}
+	if err := downloader.DownloadVoices(voiceSource, cfg.VoicesDir, cfg.Voices); err != nil {
 		log.Printf("Aviso: erro no download das vozes: %v", err)
 	}
 
@@ -46,7 +58,70 @@ func main() {
 	voices := voiceManager.ListVoices()
 	log.Printf("Vozes disponíveis: %v", voices)
 
-	ttsHandler := handlers.NewTTSHandler(voiceManager)
+	// Servidor Wyoming opcional para o Home Assistant
+	if cfg.WyomingPort != "" {
+		wyomingServer := wyoming.NewServer(voiceManager, "1.0")
+		go func() {
+			log.Printf("Servidor Wyoming iniciando na porta %s", cfg.WyomingPort)
+			if err := wyomingServer.ListenAndServe(":" + cfg.WyomingPort); err != nil {
+				log.Fatalf("Falha no servidor Wyoming: %v", err)
+			}
+		}()
+	}
+
+	// Servidor gRPC opcional, com o mesmo token da API HTTP
+	if cfg.GRPCPort != "" {
+		listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Fatalf("Falha ao abrir a porta gRPC: %v", err)
+		}
+		grpcServer := grpcapi.NewServer(voiceManager, cfg.AuthToken)
+		go func() {
+			log.Printf("Servidor gRPC iniciando na porta %s", cfg.GRPCPort)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("Falha no servidor gRPC: %v", err)
+			}
+		}()
+	}
+
+	audioCache, err := cache.New(cfg)
+	if err != nil {
+		log.Fatalf("Falha ao inicializar o cache: %v", err)
+	}
+
+	// Pacote de prompts opcional, renderizado em segundo plano
+	promptLibrary, err := prompts.New(voiceManager, cfg)
+	if err != nil {
+		log.Fatalf("Falha ao carregar o pacote de prompts: %v", err)
+	}
+
+	// Vozes atualizadas ou removidas em VOICES_DIR invalidam o cache e os
+	// prompts da voz
+	voiceEvents, _ := voiceManager.Subscribe()
+	go func() {
+		for event := range voiceEvents {
+			if event.Type == voice.VoiceRejected {
+				continue
+			}
+			if event.Type != voice.VoiceAdded {
+				audioCache.PurgeVoice(event.Voice)
+			}
+			if promptLibrary != nil {
+				if err := promptLibrary.Reload(); err != nil {
+					log.Printf("Aviso: pacote de prompts mantido: %v", err)
+				}
+			}
+		}
+	}()
+
+	ttsHandler := handlers.NewTTSHandler(voiceManager, audioCache)
+	jobsHandler := handlers.NewJobsHandler(ttsHandler, jobs.NewManager(voiceManager, cfg))
+	openAIHandler := handlers.NewOpenAIHandler(voiceManager)
+	lexiconHandler := handlers.NewLexiconHandler(voiceManager)
+	cacheHandler := handlers.NewCacheHandler(voiceManager, audioCache)
+	promptsHandler := handlers.NewPromptsHandler(voiceManager, promptLibrary)
+	voiceCatalog := downloader.NewCatalog(voiceSource, time.Duration(cfg.VoicesCatalogTTLMinutes)*time.Minute)
+	voicesAdminHandler := handlers.NewVoicesAdminHandler(voiceManager, voiceCatalog, installer.New(voiceManager, voiceCatalog))
 
 	mux := http.NewServeMux()
 
@@ -56,10 +131,35 @@ func main() {
 
 	// Rotas que exigem autenticação
 	mux.HandleFunc("/synthesize", ttsHandler.Synthesize)
+	mux.HandleFunc("GET /synthesize", ttsHandler.SynthesizeGet)
+	mux.HandleFunc("POST /synthesize/url", ttsHandler.SignURL)
 	mux.HandleFunc("/voices", ttsHandler.ListVoices)
+	mux.HandleFunc("POST /normalize", ttsHandler.Normalize)
+	mux.HandleFunc("POST /phonemize", ttsHandler.Phonemize)
+	mux.HandleFunc("GET /voices/{voice}/lexicon", lexiconHandler.List)
+	mux.HandleFunc("POST /voices/{voice}/lexicon/import", lexiconHandler.Import)
+	mux.HandleFunc("GET /voices/{voice}/lexicon/{word}", lexiconHandler.Get)
+	mux.HandleFunc("PUT /voices/{voice}/lexicon/{word}", lexiconHandler.Put)
+	mux.HandleFunc("DELETE /voices/{voice}/lexicon/{word}", lexiconHandler.Delete)
+	mux.HandleFunc("/ws/synthesize", ttsHandler.SynthesizeWS)
+	mux.HandleFunc("POST /jobs", jobsHandler.Create)
+	mux.HandleFunc("GET /jobs/{id}", jobsHandler.Get)
+	mux.HandleFunc("GET /jobs/{id}/audio", jobsHandler.Audio)
+	mux.HandleFunc("DELETE /jobs/{id}", jobsHandler.Delete)
+	mux.HandleFunc("GET /prompts", promptsHandler.List)
+	mux.HandleFunc("GET /prompts/{id}", promptsHandler.Get)
+	mux.HandleFunc("POST /v1/audio/speech", openAIHandler.Speech)
+	mux.HandleFunc("GET /admin/cache", cacheHandler.Stats)
+	mux.HandleFunc("DELETE /admin/cache", cacheHandler.Purge)
+	mux.HandleFunc("GET /admin/catalog", voicesAdminHandler.Catalog)
+	mux.HandleFunc("POST /admin/voices", voicesAdminHandler.Install)
+	mux.HandleFunc("DELETE /admin/voices/{name}", voicesAdminHandler.Remove)
+	mux.HandleFunc("POST /admin/voices/{name}/update", voicesAdminHandler.Update)
+	mux.HandleFunc("GET /admin/tasks", voicesAdminHandler.ListTasks)
+	mux.HandleFunc("GET /admin/tasks/{id}", voicesAdminHandler.GetTask)
 
 	// Aplica o middleware de autenticação nas rotas que exigem
-	handler := middleware.AuthMiddleware(cfg.AuthToken)(mux)
+	handler := middleware.AuthMiddleware(cfg.AuthToken, cfg.SignedURLSecret)(mux)
 
 	log.Printf("Servidor iniciando na porta %s", cfg.Port)
 	if err := http.ListenAndServe(":"+cfg.Port, handler); err != nil {
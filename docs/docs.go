@@ -19,48 +19,68 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/synthesize": {
-            "post": {
+        "/admin/cache": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Converte texto em áudio utilizando a voz especificada",
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Administração"
+                ],
+                "summary": "Consulta o cache de áudio",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/cache.Stats"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a entrada da chave informada (cabeçalho X-Cache-Key da síntese), todas as entradas de uma voz (inclusive de vozes já removidas) ou, sem parâmetros, todo o cache",
                 "produces": [
-                    "application/json",
-                    " audio/wav"
+                    "application/json"
                 ],
                 "tags": [
-                    "TTS"
+                    "Administração"
                 ],
-                "summary": "Sintetiza texto em áudio",
+                "summary": "Remove entradas do cache de áudio",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "base64",
-                        "description": "Formato de retorno do áudio (base64 ou binary)",
-                        "name": "format",
+                        "description": "Chave da entrada",
+                        "name": "key",
                         "in": "query"
                     },
                     {
-                        "description": "Requisição de síntese",
-                        "name": "SynthesizeRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.SynthesizeRequest"
-                        }
+                        "type": "string",
+                        "description": "Nome da voz",
+                        "name": "voice",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SynthesizeResponse"
+                            "$ref": "#/definitions/handlers.CachePurgeResponse"
                         }
                     },
                     "400": {
@@ -75,8 +95,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Chave não encontrada",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -84,26 +104,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/voices": {
+        "/admin/catalog": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retorna uma lista das vozes disponíveis para síntese",
+                "description": "Lista as vozes do manifesto do piper (VOICES_MANIFEST_URL), filtradas por idioma, qualidade ou texto. O manifesto fica em memória por VOICES_CATALOG_TTL_MINUTES.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TTS"
+                    "Administração"
+                ],
+                "summary": "Consulta o catálogo de vozes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Texto buscado na chave, no nome e no idioma",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código (pt_BR) ou família (pt) do idioma",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "x_low",
+                            "low",
+                            "medium",
+                            "high"
+                        ],
+                        "type": "string",
+                        "description": "Qualidade",
+                        "name": "quality",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Baixa o manifesto novamente",
+                        "name": "refresh",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Lista as vozes disponíveis",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ListVoicesResponse"
+                            "$ref": "#/definitions/handlers.CatalogResponse"
                         }
                     },
                     "401": {
@@ -111,55 +163,2360 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "erro": {
-                    "type": "string"
+        },
+        "/admin/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Administração"
+                ],
+                "summary": "Lista as tarefas de instalação",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListTasksResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "handlers.ListVoicesResponse": {
-            "type": "object",
-            "properties": {
-                "voices": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        "/admin/tasks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna o estado e o progresso do download (bytes baixados e total)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Administração"
+                ],
+                "summary": "Consulta uma tarefa de instalação",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id da tarefa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/installer.Task"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "handlers.SynthesizeRequest": {
-            "type": "object",
-            "properties": {
-                "text": {
-                    "type": "string"
-                },
-                "voice": {
-                    "type": "string"
+        "/admin/voices": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Baixa a voz do catálogo em segundo plano, confere o tamanho e o MD5 dos arquivos e a registra sem reiniciar o serviço. Acompanhe o progresso em GET /admin/tasks/{id}.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Administração"
+                ],
+                "summary": "Instala uma voz",
+                "parameters": [
+                    {
+                        "description": "Chave da voz no manifesto",
+                        "name": "InstallVoiceRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InstallVoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/installer.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "handlers.SynthesizeResponse": {
-            "type": "object",
-            "properties": {
-                "audio": {
-                    "type": "string"
-                },
-                "duration": {
-                    "type": "number"
-                },
-                "text": {
-                    "type": "string"
-                },
-                "voice": {
+        "/admin/voices/{name}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a voz da lista e apaga os arquivos. As requisições em andamento são concluídas.",
+                "tags": [
+                    "Administração"
+                ],
+                "summary": "Remove uma voz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nome da voz",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/voices/{name}/update": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Compara os arquivos da voz com o manifesto atual e, se diferirem, baixa a nova versão em segundo plano. A versão anterior atende as requisições até a troca.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Administração"
+                ],
+                "summary": "Atualiza uma voz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nome da voz",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/installer.Task"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Enfileira a síntese do texto e retorna o id do job para acompanhamento. Ao terminar, o estado final é enviado para callback_url (se informado) com a assinatura HMAC-SHA256 de \"\u003cX-GoTTS-Timestamp\u003e.\u003ccorpo\u003e\" em X-GoTTS-Signature. Endereços internos (loopback, redes privadas e link-local) são recusados, exceto os hosts de WEBHOOK_ALLOWED_HOSTS.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Cria um job de síntese",
+                "parameters": [
+                    {
+                        "description": "Requisição de síntese",
+                        "name": "JobRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/jobs.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna o estado e o progresso (frases ou segmentos SSML sintetizados) do job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Consulta um job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id do job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jobs.Job"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Jobs na fila ou em execução são cancelados e continuam consultáveis até expirar; jobs finalizados são removidos junto com o áudio",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Cancela ou remove um job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id do job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jobs.Job"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/audio": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna o áudio gerado no formato solicitado na criação do job",
+                "produces": [
+                    "audio/wav",
+                    " audio/ogg",
+                    " audio/mpeg",
+                    " audio/pcm",
+                    " audio/PCMU",
+                    " audio/PCMA"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Baixa o áudio de um job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id do job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/normalize": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna o texto com o léxico da voz aplicado e números, valores, datas, horários, ordinais, porcentagens, telefones, CPF/CNPJ e abreviações por extenso, como é enviado ao engine na síntese",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TTS"
+                ],
+                "summary": "Normaliza um texto",
+                "parameters": [
+                    {
+                        "description": "Texto e voz ou idioma",
+                        "name": "NormalizeRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NormalizeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NormalizeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/phonemize": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna os fonemas IPA (espeak-ng) que a voz usaria para o texto, após o léxico e a normalização. O resultado pode ser ajustado e enviado a /synthesize com text_type \"phonemes\". Com o espeak-ng da distribuição, alguns fonemas podem diferir dos obtidos pelo fork embutido no piper.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TTS"
+                ],
+                "summary": "Converte um texto em fonemas",
+                "parameters": [
+                    {
+                        "description": "Texto e voz",
+                        "name": "PhonemizeRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PhonemizeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PhonemizeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/prompts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna os prompts do pacote (PROMPTS_FILE) com a situação da renderização: pending, rendering, ready ou failed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "Lista os prompts pré-renderizados",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListPromptsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/prompts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna o áudio pré-renderizado do prompt. Se ainda não estiver pronto, o prompt é renderizado na hora. O ETag muda quando o texto, os parâmetros, o léxico ou o modelo da voz mudam.",
+                "produces": [
+                    "audio/wav",
+                    " audio/ogg",
+                    " audio/mpeg",
+                    " audio/pcm",
+                    " audio/PCMU",
+                    " audio/PCMA"
+                ],
+                "tags": [
+                    "Prompts"
+                ],
+                "summary": "Obtém o áudio de um prompt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id do prompt",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Intervalo de bytes",
+                        "name": "Range",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag já obtido",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "206": {
+                        "description": "Partial Content"
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/synthesize": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Variante cacheável de POST /synthesize para uso direto em \u003caudio\u003e e CDNs. Retorna o áudio binário com ETag determinístico (derivado do texto normalizado, da voz e da versão do seu modelo, da prosódia e do formato; muda quando a voz é atualizada), responde 304 a If-None-Match e 206 a Range. Aceita o token de autenticação ou uma URL assinada gerada por POST /synthesize/url.",
+                "produces": [
+                    "application/json",
+                    " audio/wav",
+                    " audio/ogg",
+                    " audio/mpeg",
+                    " audio/pcm",
+                    " audio/PCMU",
+                    " audio/PCMA",
+                    " application/x-subrip",
+                    " text/vtt"
+                ],
+                "tags": [
+                    "TTS"
+                ],
+                "summary": "Sintetiza texto em áudio via GET",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Texto",
+                        "name": "text",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome da voz",
+                        "name": "voice",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locutor por id ou nome",
+                        "name": "speaker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "text",
+                        "description": "text, ssml ou phonemes",
+                        "name": "text_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Normaliza o texto",
+                        "name": "normalize",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "binary",
+                            "srt",
+                            "vtt"
+                        ],
+                        "type": "string",
+                        "default": "binary",
+                        "description": "Áudio ou legendas",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "wav",
+                        "description": "Codec do áudio; também negociado via Accept",
+                        "name": "encoding",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bitrate em bps para formatos comprimidos",
+                        "name": "bitrate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Taxa de amostragem de saída em Hz",
+                        "name": "sample_rate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Velocidade da fala",
+                        "name": "speed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Duração dos fonemas",
+                        "name": "length_scale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Variação de entonação",
+                        "name": "noise_scale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Variação na duração dos fonemas",
+                        "name": "noise_w",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Segundos de silêncio após cada frase",
+                        "name": "sentence_silence",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Validade da URL assinada (segundos Unix)",
+                        "name": "expires",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assinatura da URL",
+                        "name": "signature",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Intervalo de bytes",
+                        "name": "Range",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag já obtido",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "206": {
+                        "description": "Partial Content"
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Converte texto em áudio utilizando a voz especificada",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    " audio/wav",
+                    " audio/ogg",
+                    " audio/mpeg",
+                    " audio/pcm",
+                    " audio/PCMU",
+                    " audio/PCMA",
+                    " application/x-subrip",
+                    " text/vtt"
+                ],
+                "tags": [
+                    "TTS"
+                ],
+                "summary": "Sintetiza texto em áudio",
+                "parameters": [
+                    {
+                        "enum": [
+                            "base64",
+                            "binary",
+                            "srt",
+                            "vtt"
+                        ],
+                        "type": "string",
+                        "default": "base64",
+                        "description": "Formato de retorno: base64, binary, ou legendas srt ou vtt sincronizadas com o áudio",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "wav",
+                        "description": "Codec do áudio (wav, ogg_opus, mp3, pcm_s16le, mulaw ou alaw); também aceito no corpo ou via Accept",
+                        "name": "encoding",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Envia o áudio frase a frase via chunked transfer (wav, pcm_s16le, mulaw ou alaw); ignora format",
+                        "name": "stream",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Requisição de síntese",
+                        "name": "SynthesizeRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SynthesizeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SynthesizeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RangeErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/synthesize/url": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna o caminho de GET /synthesize com os parâmetros da síntese, a validade e a assinatura HMAC. A URL dispensa o token de autenticação até expirar e pode ser usada diretamente em \u003caudio\u003e ou \u003ctrack\u003e.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TTS"
+                ],
+                "summary": "Gera uma URL assinada de síntese",
+                "parameters": [
+                    {
+                        "description": "Parâmetros da síntese",
+                        "name": "SignURLRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SignURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SignURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/audio/speech": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Implementa POST /v1/audio/speech da OpenAI. As vozes da OpenAI (alloy, nova, ...) são mapeadas para vozes instaladas via OPENAI_VOICES; nomes de vozes instaladas também são aceitos. Formatos: mp3, opus, wav e pcm (24 kHz, 16 bits, mono).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "audio/mpeg",
+                    " audio/ogg",
+                    " audio/wav",
+                    " audio/pcm"
+                ],
+                "tags": [
+                    "OpenAI"
+                ],
+                "summary": "Síntese compatível com a OpenAI",
+                "parameters": [
+                    {
+                        "description": "Requisição de síntese",
+                        "name": "OpenAISpeechRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OpenAISpeechRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OpenAIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OpenAIError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OpenAIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/voices": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna uma lista das vozes disponíveis para síntese e os locutores de cada voz multi-locutor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TTS"
+                ],
+                "summary": "Lista as vozes disponíveis",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListVoicesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/voices/{voice}/lexicon": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna as pronúncias personalizadas da voz em JSON, CSV (word,alias,phonemes) ou W3C PLS",
+                "produces": [
+                    "application/json",
+                    " text/csv",
+                    " application/pls+xml"
+                ],
+                "tags": [
+                    "Léxico"
+                ],
+                "summary": "Lista ou exporta o léxico de uma voz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nome da voz",
+                        "name": "voice",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "Formato (json, csv ou pls)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LexiconResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/voices/{voice}/lexicon/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "O corpo é o arquivo no formato indicado em format ou, na falta dele, pelo Content-Type (text/csv, application/pls+xml ou application/json). Com mode=replace o léxico atual é descartado; com merge (padrão) as palavras do arquivo são incluídas ou atualizadas.",
+                "consumes": [
+                    "application/json",
+                    " text/csv",
+                    " application/pls+xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Léxico"
+                ],
+                "summary": "Importa um léxico",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nome da voz",
+                        "name": "voice",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato do arquivo (json, csv ou pls)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "merge",
+                        "description": "merge ou replace",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LexiconImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/voices/{voice}/lexicon/{word}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Léxico"
+                ],
+                "summary": "Consulta uma palavra do léxico",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nome da voz",
+                        "name": "voice",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Palavra",
+                        "name": "word",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lexicon.Entry"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "A palavra é substituída no texto sem diferenciar maiúsculas e apenas quando isolada (não faz parte de outra palavra). Fonemas são enviados ao engine entre [[ ]].",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Léxico"
+                ],
+                "summary": "Inclui ou altera uma palavra do léxico",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nome da voz",
+                        "name": "voice",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Palavra",
+                        "name": "word",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pronúncia",
+                        "name": "LexiconEntryRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LexiconEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lexicon.Entry"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/lexicon.Entry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Léxico"
+                ],
+                "summary": "Remove uma palavra do léxico",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nome da voz",
+                        "name": "voice",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Palavra",
+                        "name": "word",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/synthesize": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Recebe texto em partes e devolve o áudio de cada frase assim que fica pronto. Mensagens do cliente: {\"type\":\"start\", ...campos de /synthesize}, {\"type\":\"text\",\"text\":\"...\"}, {\"type\":\"flush\"} e {\"type\":\"cancel\"}. Eventos do servidor: started, sentence_started, sentence_finished, flushed, cancelled e error, com o áudio em mensagens binárias (wav, pcm_s16le, mulaw ou alaw). O token pode ser enviado no cabeçalho Authorization, no parâmetro token ou no subprotocolo \"bearer.\u003ctoken\u003e\", sempre junto com \"gotts\", o único subprotocolo confirmado pelo servidor. Navegadores só conectam a partir da mesma origem ou das origens em WS_ALLOWED_ORIGINS.",
+                "tags": [
+                    "TTS"
+                ],
+                "summary": "Sessão de síntese via WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token de autenticação (alternativa ao cabeçalho Authorization)",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "cache.Stats": {
+            "type": "object",
+            "properties": {
+                "disk_bytes": {
+                    "type": "integer"
+                },
+                "disk_entries": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "description": "Entradas em memória",
+                    "type": "integer"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "memory_bytes": {
+                    "description": "Bytes em memória",
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                }
+            }
+        },
+        "downloader.LanguageInfo": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "country_english": {
+                    "type": "string"
+                },
+                "family": {
+                    "type": "string"
+                },
+                "name_english": {
+                    "type": "string"
+                },
+                "name_native": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CachePurgeResponse": {
+            "type": "object",
+            "properties": {
+                "removed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.CatalogResponse": {
+            "type": "object",
+            "properties": {
+                "total": {
+                    "type": "integer"
+                },
+                "voices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.CatalogVoice"
+                    }
+                }
+            }
+        },
+        "handlers.CatalogVoice": {
+            "type": "object",
+            "properties": {
+                "installed": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "language": {
+                    "$ref": "#/definitions/downloader.LanguageInfo"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quality": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "erro": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.InstallVoiceRequest": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "pt_BR-faber-medium"
+                }
+            }
+        },
+        "handlers.JobRequest": {
+            "type": "object",
+            "properties": {
+                "bitrate": {
+                    "description": "Bitrate em bps para formatos comprimidos (mp3: 32000 a 192000, CBR; até 160000 abaixo de 32 kHz)",
+                    "type": "integer"
+                },
+                "callback_url": {
+                    "description": "URL que recebe um POST com o estado final do job (concluído ou com\nfalha), assinado com HMAC-SHA256 no cabeçalho X-GoTTS-Signature",
+                    "type": "string"
+                },
+                "encoding": {
+                    "description": "Formato de saída do áudio; quando omitido usa o parâmetro encoding da\nquery string ou o cabeçalho Accept (format=binary)",
+                    "type": "string",
+                    "enum": [
+                        "wav",
+                        "ogg_opus",
+                        "mp3",
+                        "pcm_s16le",
+                        "mulaw",
+                        "alaw"
+                    ]
+                },
+                "length_scale": {
+                    "description": "Duração dos fonemas (maior = mais lento)",
+                    "type": "number",
+                    "maximum": 4,
+                    "minimum": 0.25
+                },
+                "noise_scale": {
+                    "description": "Variação de entonação",
+                    "type": "number",
+                    "maximum": 2,
+                    "minimum": 0
+                },
+                "noise_w": {
+                    "description": "Variação na duração dos fonemas",
+                    "type": "number",
+                    "maximum": 2,
+                    "minimum": 0
+                },
+                "normalize": {
+                    "description": "Expande números, valores, datas, horários e abreviações conforme o\nidioma da voz antes da síntese (padrão: true)",
+                    "type": "boolean"
+                },
+                "sample_rate": {
+                    "description": "Taxa de amostragem de saída em Hz (mulaw/alaw: 8000 por padrão)",
+                    "type": "integer"
+                },
+                "sentence_silence": {
+                    "description": "Segundos de silêncio após cada frase",
+                    "type": "number",
+                    "maximum": 5,
+                    "minimum": 0
+                },
+                "speaker": {
+                    "description": "Locutor por id ou nome (modelos multi-locutor)",
+                    "type": "string"
+                },
+                "speed": {
+                    "description": "Parâmetros opcionais de prosódia; quando omitidos usam o padrão da voz.\nValores diferentes do padrão usam um worker piper próprio, mantido para\nos PIPER_PROSODY_POOLS conjuntos usados mais recentemente em cada voz;\nfora deles cada requisição inicia um processo que recarrega o modelo.",
+                    "type": "number",
+                    "maximum": 4,
+                    "minimum": 0.25
+                },
+                "text": {
+                    "type": "string"
+                },
+                "text_type": {
+                    "description": "Tipo do texto: \"text\" (padrão); \"ssml\", com \u003cspeak\u003e, \u003cbreak\u003e,\n\u003csay-as\u003e, \u003cprosody rate\u003e, \u003cvoice name\u003e, \u003csub\u003e, \u003cp\u003e e \u003cs\u003e; ou\n\"phonemes\", fonemas IPA do espeak como os retornados por /phonemize",
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "ssml",
+                        "phonemes"
+                    ]
+                },
+                "timings": {
+                    "description": "Inclui na resposta JSON o início e o fim de cada frase e palavra (os\ndas palavras são estimados); implícito em format=srt e format=vtt",
+                    "type": "boolean"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LexiconEntryRequest": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "description": "Grafia lida no lugar da palavra",
+                    "type": "string"
+                },
+                "phonemes": {
+                    "description": "Fonemas IPA",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LexiconImportResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "Palavras que não existiam no léxico",
+                    "type": "integer"
+                },
+                "imported": {
+                    "description": "Entradas lidas do arquivo",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Entradas no léxico após a importação",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.LexiconResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/lexicon.Entry"
+                    }
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ListPromptsResponse": {
+            "type": "object",
+            "properties": {
+                "prompts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/prompts.Info"
+                    }
+                }
+            }
+        },
+        "handlers.ListTasksResponse": {
+            "type": "object",
+            "properties": {
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/installer.Task"
+                    }
+                }
+            }
+        },
+        "handlers.ListVoicesResponse": {
+            "type": "object",
+            "properties": {
+                "speakers": {
+                    "description": "Locutores por voz, apenas para modelos multi-locutor",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/voice.Speaker"
+                        }
+                    }
+                },
+                "voices": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.NormalizeRequest": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "description": "Idioma quando a voz não é informada ou não declara idioma (pt_BR, en_US)",
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "voice": {
+                    "description": "Usa o idioma e o léxico da voz",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.NormalizeResponse": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "normalized": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OpenAIError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/handlers.OpenAIErrorDetail"
+                }
+            }
+        },
+        "handlers.OpenAIErrorDetail": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "param": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OpenAISpeechRequest": {
+            "type": "object",
+            "properties": {
+                "input": {
+                    "type": "string"
+                },
+                "model": {
+                    "description": "Aceito por compatibilidade e ignorado",
+                    "type": "string"
+                },
+                "response_format": {
+                    "type": "string",
+                    "enum": [
+                        "mp3",
+                        "opus",
+                        "wav",
+                        "pcm"
+                    ]
+                },
+                "speed": {
+                    "type": "number",
+                    "maximum": 4,
+                    "minimum": 0.25
+                },
+                "stream_format": {
+                    "type": "string",
+                    "enum": [
+                        "audio"
+                    ]
+                },
+                "voice": {
+                    "description": "Nome OpenAI (OPENAI_VOICES) ou de uma voz instalada",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PhonemizeRequest": {
+            "type": "object",
+            "properties": {
+                "normalize": {
+                    "description": "Aplica a normalização do texto antes da fonemização (padrão: true)",
+                    "type": "boolean"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PhonemizeResponse": {
+            "type": "object",
+            "properties": {
+                "phonemes": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RangeErrorResponse": {
+            "type": "object",
+            "properties": {
+                "campo": {
+                    "type": "string"
+                },
+                "erro": {
+                    "type": "string"
+                },
+                "maximo": {
+                    "type": "number"
+                },
+                "minimo": {
+                    "type": "number"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.SignURLRequest": {
+            "type": "object",
+            "properties": {
+                "bitrate": {
+                    "description": "Bitrate em bps para formatos comprimidos (mp3: 32000 a 192000, CBR; até 160000 abaixo de 32 kHz)",
+                    "type": "integer"
+                },
+                "encoding": {
+                    "description": "Formato de saída do áudio; quando omitido usa o parâmetro encoding da\nquery string ou o cabeçalho Accept (format=binary)",
+                    "type": "string",
+                    "enum": [
+                        "wav",
+                        "ogg_opus",
+                        "mp3",
+                        "pcm_s16le",
+                        "mulaw",
+                        "alaw"
+                    ]
+                },
+                "expires_in": {
+                    "description": "Validade em segundos (padrão: SIGNED_URL_TTL_MINUTES; máximo: SIGNED_URL_MAX_TTL_MINUTES)",
+                    "type": "integer"
+                },
+                "format": {
+                    "description": "Áudio (padrão) ou legendas",
+                    "type": "string",
+                    "enum": [
+                        "binary",
+                        "srt",
+                        "vtt"
+                    ]
+                },
+                "length_scale": {
+                    "description": "Duração dos fonemas (maior = mais lento)",
+                    "type": "number",
+                    "maximum": 4,
+                    "minimum": 0.25
+                },
+                "noise_scale": {
+                    "description": "Variação de entonação",
+                    "type": "number",
+                    "maximum": 2,
+                    "minimum": 0
+                },
+                "noise_w": {
+                    "description": "Variação na duração dos fonemas",
+                    "type": "number",
+                    "maximum": 2,
+                    "minimum": 0
+                },
+                "normalize": {
+                    "description": "Expande números, valores, datas, horários e abreviações conforme o\nidioma da voz antes da síntese (padrão: true)",
+                    "type": "boolean"
+                },
+                "sample_rate": {
+                    "description": "Taxa de amostragem de saída em Hz (mulaw/alaw: 8000 por padrão)",
+                    "type": "integer"
+                },
+                "sentence_silence": {
+                    "description": "Segundos de silêncio após cada frase",
+                    "type": "number",
+                    "maximum": 5,
+                    "minimum": 0
+                },
+                "speaker": {
+                    "description": "Locutor por id ou nome (modelos multi-locutor)",
+                    "type": "string"
+                },
+                "speed": {
+                    "description": "Parâmetros opcionais de prosódia; quando omitidos usam o padrão da voz.\nValores diferentes do padrão usam um worker piper próprio, mantido para\nos PIPER_PROSODY_POOLS conjuntos usados mais recentemente em cada voz;\nfora deles cada requisição inicia um processo que recarrega o modelo.",
+                    "type": "number",
+                    "maximum": 4,
+                    "minimum": 0.25
+                },
+                "text": {
+                    "type": "string"
+                },
+                "text_type": {
+                    "description": "Tipo do texto: \"text\" (padrão); \"ssml\", com \u003cspeak\u003e, \u003cbreak\u003e,\n\u003csay-as\u003e, \u003cprosody rate\u003e, \u003cvoice name\u003e, \u003csub\u003e, \u003cp\u003e e \u003cs\u003e; ou\n\"phonemes\", fonemas IPA do espeak como os retornados por /phonemize",
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "ssml",
+                        "phonemes"
+                    ]
+                },
+                "timings": {
+                    "description": "Inclui na resposta JSON o início e o fim de cada frase e palavra (os\ndas palavras são estimados); implícito em format=srt e format=vtt",
+                    "type": "boolean"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SignURLResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SynthesizeRequest": {
+            "type": "object",
+            "properties": {
+                "bitrate": {
+                    "description": "Bitrate em bps para formatos comprimidos (mp3: 32000 a 192000, CBR; até 160000 abaixo de 32 kHz)",
+                    "type": "integer"
+                },
+                "encoding": {
+                    "description": "Formato de saída do áudio; quando omitido usa o parâmetro encoding da\nquery string ou o cabeçalho Accept (format=binary)",
+                    "type": "string",
+                    "enum": [
+                        "wav",
+                        "ogg_opus",
+                        "mp3",
+                        "pcm_s16le",
+                        "mulaw",
+                        "alaw"
+                    ]
+                },
+                "length_scale": {
+                    "description": "Duração dos fonemas (maior = mais lento)",
+                    "type": "number",
+                    "maximum": 4,
+                    "minimum": 0.25
+                },
+                "noise_scale": {
+                    "description": "Variação de entonação",
+                    "type": "number",
+                    "maximum": 2,
+                    "minimum": 0
+                },
+                "noise_w": {
+                    "description": "Variação na duração dos fonemas",
+                    "type": "number",
+                    "maximum": 2,
+                    "minimum": 0
+                },
+                "normalize": {
+                    "description": "Expande números, valores, datas, horários e abreviações conforme o\nidioma da voz antes da síntese (padrão: true)",
+                    "type": "boolean"
+                },
+                "sample_rate": {
+                    "description": "Taxa de amostragem de saída em Hz (mulaw/alaw: 8000 por padrão)",
+                    "type": "integer"
+                },
+                "sentence_silence": {
+                    "description": "Segundos de silêncio após cada frase",
+                    "type": "number",
+                    "maximum": 5,
+                    "minimum": 0
+                },
+                "speaker": {
+                    "description": "Locutor por id ou nome (modelos multi-locutor)",
+                    "type": "string"
+                },
+                "speed": {
+                    "description": "Parâmetros opcionais de prosódia; quando omitidos usam o padrão da voz.\nValores diferentes do padrão usam um worker piper próprio, mantido para\nos PIPER_PROSODY_POOLS conjuntos usados mais recentemente em cada voz;\nfora deles cada requisição inicia um processo que recarrega o modelo.",
+                    "type": "number",
+                    "maximum": 4,
+                    "minimum": 0.25
+                },
+                "text": {
+                    "type": "string"
+                },
+                "text_type": {
+                    "description": "Tipo do texto: \"text\" (padrão); \"ssml\", com \u003cspeak\u003e, \u003cbreak\u003e,\n\u003csay-as\u003e, \u003cprosody rate\u003e, \u003cvoice name\u003e, \u003csub\u003e, \u003cp\u003e e \u003cs\u003e; ou\n\"phonemes\", fonemas IPA do espeak como os retornados por /phonemize",
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "ssml",
+                        "phonemes"
+                    ]
+                },
+                "timings": {
+                    "description": "Inclui na resposta JSON o início e o fim de cada frase e palavra (os\ndas palavras são estimados); implícito em format=srt e format=vtt",
+                    "type": "boolean"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SynthesizeResponse": {
+            "type": "object",
+            "properties": {
+                "audio": {
+                    "type": "string"
+                },
+                "codec": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "number"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "sample_rate": {
+                    "type": "integer"
+                },
+                "sentences": {
+                    "description": "Intervalos de fala em segundos, presentes com timings=true. Os tempos\ndas frases são medidos no áudio; os das palavras são estimados dentro\nde cada frase e marcados com estimated.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/timing.Sentence"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "installer.Kind": {
+            "type": "string",
+            "enum": [
+                "install",
+                "update"
+            ],
+            "x-enum-varnames": [
+                "KindInstall",
+                "KindUpdate"
+            ]
+        },
+        "installer.Progress": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "installer.Status": {
+            "type": "string",
+            "enum": [
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "StatusRunning",
+                "StatusCompleted",
+                "StatusFailed"
+            ]
+        },
+        "installer.Task": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "erro": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/installer.Kind"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "progress": {
+                    "$ref": "#/definitions/installer.Progress"
+                },
+                "status": {
+                    "$ref": "#/definitions/installer.Status"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "jobs.Job": {
+            "type": "object",
+            "properties": {
+                "audio_url": {
+                    "description": "Preenchidos quando o job é concluído",
+                    "type": "string"
+                },
+                "codec": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "number"
+                },
+                "encoding": {
+                    "type": "string"
+                },
+                "erro": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "progress": {
+                    "$ref": "#/definitions/jobs.Progress"
+                },
+                "sample_rate": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/jobs.Status"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "jobs.Progress": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "jobs.Status": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "running",
+                "completed",
+                "failed",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "StatusQueued",
+                "StatusRunning",
+                "StatusCompleted",
+                "StatusFailed",
+                "StatusCancelled"
+            ]
+        },
+        "lexicon.Entry": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "description": "Grafia lida no lugar da palavra",
+                    "type": "string"
+                },
+                "phonemes": {
+                    "description": "Fonemas IPA, enviados ao engine entre [[ ]]",
+                    "type": "string"
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "prompts.Info": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "number"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "hash": {
+                    "description": "Muda com o texto, os parâmetros, o léxico ou o modelo da voz",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "rendered_at": {
+                    "type": "string"
+                },
+                "sample_rate": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/prompts.Status"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "prompts.Status": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "rendering",
+                "ready",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusRendering",
+                "StatusReady",
+                "StatusFailed"
+            ]
+        },
+        "timing.Sentence": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "number"
+                },
+                "start": {
+                    "type": "number"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "words": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/timing.Word"
+                    }
+                }
+            }
+        },
+        "timing.Word": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "number"
+                },
+                "estimated": {
+                    "description": "Indica que os tempos foram estimados pela quantidade de letras, e não\nmedidos no áudio",
+                    "type": "boolean",
+                    "example": true
+                },
+                "start": {
+                    "type": "number"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "voice.Speaker": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 }
             }
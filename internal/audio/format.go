@@ -0,0 +1,287 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"tts-api/internal/audio/wav"
+)
+
+// Encoding identifica o formato de saída do áudio
+type Encoding string
+
+const (
+	EncodingWAV     Encoding = "wav"
+	EncodingOggOpus Encoding = "ogg_opus"
+	EncodingMP3     Encoding = "mp3"
+	EncodingPCM     Encoding = "pcm_s16le" // PCM 16 bits sem cabeçalho
+	EncodingMulaw   Encoding = "mulaw"     // G.711 μ-law sem cabeçalho
+	EncodingAlaw    Encoding = "alaw"      // G.711 A-law sem cabeçalho
+)
+
+// Limites da taxa de amostragem de saída
+const (
+	MinSampleRate = 8000
+	MaxSampleRate = 48000
+
+	// telephonySampleRate é a taxa padrão do G.711
+	telephonySampleRate = 8000
+)
+
+// MP3SampleRates são as taxas de amostragem aceitas pelo encoder MP3
+var MP3SampleRates = []int{8000, 11025, 12000, 16000, 22050, 24000, 32000, 44100, 48000}
+
+// ErrCodecUnavailable indica que o binário foi compilado sem o codec
+var ErrCodecUnavailable = errors.New("codec não disponível neste build")
+
+// encodingMimeTypes relaciona cada formato ao Content-Type da resposta;
+// formatos sem cabeçalho recebem os parâmetros de taxa em Encode. O PCM é
+// little-endian, por isso não usa audio/L16, que é big-endian (RFC 2586).
+var encodingMimeTypes = map[Encoding]string{
+	EncodingWAV:     "audio/wav",
+	EncodingOggOpus: "audio/ogg; codecs=opus",
+	EncodingMP3:     "audio/mpeg",
+	EncodingPCM:     "audio/pcm",
+	EncodingMulaw:   "audio/PCMU",
+	EncodingAlaw:    "audio/PCMA",
+}
+
+// encodingCodecs relaciona cada formato ao codec do fluxo de áudio
+var encodingCodecs = map[Encoding]string{
+	EncodingWAV:     "pcm_s16le",
+	EncodingOggOpus: "opus",
+	EncodingMP3:     "mp3",
+	EncodingPCM:     "pcm_s16le",
+	EncodingMulaw:   "pcm_mulaw",
+	EncodingAlaw:    "pcm_alaw",
+}
+
+// acceptEncodings relaciona tipos do cabeçalho Accept aos formatos
+var acceptEncodings = map[string]Encoding{
+	"audio/wav":   EncodingWAV,
+	"audio/wave":  EncodingWAV,
+	"audio/x-wav": EncodingWAV,
+	"audio/ogg":   EncodingOggOpus,
+	"audio/opus":  EncodingOggOpus,
+	"audio/mpeg":  EncodingMP3,
+	"audio/mp3":   EncodingMP3,
+	"audio/pcm":   EncodingPCM,
+	"audio/pcmu":  EncodingMulaw,
+	"audio/basic": EncodingMulaw,
+	"audio/pcma":  EncodingAlaw,
+}
+
+// ParseEncoding valida o nome de um formato de saída
+func ParseEncoding(value string) (Encoding, error) {
+	enc := Encoding(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := encodingMimeTypes[enc]; !ok {
+		return "", fmt.Errorf("encoding %q não suportado (use um de %v)", value, Encodings())
+	}
+	return enc, nil
+}
+
+// Encodings lista os formatos de saída conhecidos
+func Encodings() []Encoding {
+	return []Encoding{EncodingWAV, EncodingOggOpus, EncodingMP3, EncodingPCM, EncodingMulaw, EncodingAlaw}
+}
+
+// EncodingFromAccept escolhe o formato a partir do cabeçalho Accept,
+// respeitando a ordem em que os tipos foram listados
+func EncodingFromAccept(accept string) (Encoding, bool) {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if enc, ok := acceptEncodings[mediaType]; ok {
+			return enc, true
+		}
+	}
+	return "", false
+}
+
+// MimeType retorna o Content-Type do formato
+func (e Encoding) MimeType() string {
+	return encodingMimeTypes[e]
+}
+
+// Codec retorna o nome do codec usado pelo formato
+func (e Encoding) Codec() string {
+	return encodingCodecs[e]
+}
+
+// Options configura os codecs de saída
+type Options struct {
+	// SampleRate é a taxa de saída; zero mantém a taxa gerada pelo engine
+	// (ou 8 kHz para G.711). Para Opus vale Opus.SampleRate.
+	SampleRate int
+	Opus       OpusOptions
+	MP3        MP3Options
+}
+
+// Validate verifica as opções relevantes para o formato
+func (o Options) Validate(enc Encoding) error {
+	switch enc {
+	case EncodingOggOpus:
+		return o.Opus.Validate()
+	case EncodingMP3:
+		if err := o.MP3.Validate(); err != nil {
+			return err
+		}
+		if o.SampleRate != 0 && !containsInt(MP3SampleRates, o.SampleRate) {
+			return fmt.Errorf("taxa de amostragem mp3 deve ser uma de %v", MP3SampleRates)
+		}
+	default:
+		if o.SampleRate != 0 && (o.SampleRate < MinSampleRate || o.SampleRate > MaxSampleRate) {
+			return fmt.Errorf("taxa de amostragem deve estar entre %d e %d Hz", MinSampleRate, MaxSampleRate)
+		}
+	}
+	return nil
+}
+
+// ValidateFor valida as opções como Validate e, com a taxa de amostragem da
+// voz (source), o bitrate mp3 na taxa de saída
+func (o Options) ValidateFor(enc Encoding, source int) error {
+	if err := o.Validate(enc); err != nil {
+		return err
+	}
+	if enc == EncodingMP3 && source > 0 {
+		return o.MP3.ValidateSampleRate(OutputSampleRate(enc, o, source))
+	}
+	return nil
+}
+
+// FitMP3Bitrate reduz o bitrate mp3 ao maior aceito na taxa de saída. É
+// aplicado ao bitrate padrão da configuração; o informado pelo cliente é
+// conferido por ValidateFor.
+func (o *Options) FitMP3Bitrate(enc Encoding, source int) {
+	if enc == EncodingMP3 && source > 0 {
+		o.MP3.Bitrate = min(o.MP3.Bitrate, MP3MaxBitrate(OutputSampleRate(enc, *o, source)))
+	}
+}
+
+// Encoded é o resultado da codificação de um áudio
+type Encoded struct {
+	Data       []byte
+	Encoding   Encoding
+	MimeType   string
+	Codec      string
+	SampleRate int
+	Duration   float64 // segundos, calculados a partir do fluxo codificado
+}
+
+// Encode converte um WAV gerado pelo engine para o formato informado,
+// reamostrando quando necessário
+func Encode(data []byte, enc Encoding, opts Options) (*Encoded, error) {
+	if err := opts.Validate(enc); err != nil {
+		return nil, err
+	}
+
+	pcm, err := wav.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if enc != EncodingOggOpus {
+		if rate := OutputSampleRate(enc, opts, pcm.SampleRate); rate != pcm.SampleRate {
+			pcm = Resample(pcm, rate)
+		}
+	}
+
+	result := &Encoded{
+		Encoding:   enc,
+		MimeType:   enc.MimeType(),
+		Codec:      enc.Codec(),
+		SampleRate: pcm.SampleRate,
+	}
+
+	switch enc {
+	case EncodingWAV:
+		result.Data = wav.Encode(pcm)
+		header, _, err := wav.Parse(result.Data)
+		if err != nil {
+			return nil, err
+		}
+		result.Duration = header.Duration()
+	case EncodingPCM, EncodingMulaw, EncodingAlaw:
+		result.Data = encodeRaw(pcm, enc)
+		result.MimeType = rawMimeType(enc, pcm.SampleRate, pcm.Channels)
+		result.Duration = rawDuration(len(result.Data), rawBytesPerSample(enc), pcm.Channels, pcm.SampleRate)
+	case EncodingOggOpus:
+		if result.Data, err = EncodeOggOpus(pcm, opts.Opus); err != nil {
+			return nil, err
+		}
+		result.SampleRate = opts.Opus.SampleRate
+		if result.Duration, err = OggOpusDuration(result.Data); err != nil {
+			return nil, err
+		}
+	case EncodingMP3:
+		if result.Data, err = EncodeMP3(pcm, opts.MP3); err != nil {
+			return nil, err
+		}
+		if result.Duration, err = MP3Duration(result.Data); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("encoding %q não suportado", enc)
+	}
+	return result, nil
+}
+
+// OutputSampleRate retorna a taxa de saída do formato para um áudio gerado
+// na taxa source
+func OutputSampleRate(enc Encoding, opts Options, source int) int {
+	switch {
+	case opts.SampleRate != 0:
+		return opts.SampleRate
+	case enc == EncodingMulaw || enc == EncodingAlaw:
+		return telephonySampleRate
+	default:
+		return source
+	}
+}
+
+// encodeRaw gera as amostras sem cabeçalho nos formatos PCM e G.711
+func encodeRaw(pcm *PCM, enc Encoding) []byte {
+	switch enc {
+	case EncodingMulaw:
+		return EncodeMulaw(pcm.Samples)
+	case EncodingAlaw:
+		return EncodeAlaw(pcm.Samples)
+	default:
+		return pcmBytes(pcm.Samples)
+	}
+}
+
+func rawBytesPerSample(enc Encoding) int {
+	if enc == EncodingPCM || enc == EncodingWAV {
+		return 2
+	}
+	return 1
+}
+
+func rawMimeType(enc Encoding, sampleRate, channels int) string {
+	mimeType := fmt.Sprintf("%s; rate=%d; channels=%d", enc.MimeType(), sampleRate, channels)
+	if enc == EncodingPCM {
+		mimeType += "; encoding=signed-int; bits=16; endian=little"
+	}
+	return mimeType
+}
+
+// rawDuration calcula a duração de um fluxo sem cabeçalho
+func rawDuration(size, bytesPerSample, channels, sampleRate int) float64 {
+	if channels == 0 || sampleRate == 0 {
+		return 0
+	}
+	return float64(size/(bytesPerSample*channels)) / float64(sampleRate)
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package audio
+
+import (
+	"testing"
+	"tts-api/internal/audio/wav"
+)
+
+func TestRawMimeType(t *testing.T) {
+	data := wav.Encode(&PCM{SampleRate: 22050, Channels: 1, Samples: make([]int16, 2205)})
+
+	tests := []struct {
+		encoding Encoding
+		want     string
+	}{
+		{EncodingPCM, "audio/pcm; rate=22050; channels=1; encoding=signed-int; bits=16; endian=little"},
+		{EncodingMulaw, "audio/PCMU; rate=8000; channels=1"},
+		{EncodingAlaw, "audio/PCMA; rate=8000; channels=1"},
+	}
+	for _, tt := range tests {
+		encoded, err := Encode(data, tt.encoding, Options{})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.encoding, err)
+		}
+		if encoded.MimeType != tt.want {
+			t.Errorf("%s: Content-Type %q, esperado %q", tt.encoding, encoded.MimeType, tt.want)
+		}
+	}
+
+	if enc, ok := EncodingFromAccept("audio/pcm;rate=16000, audio/wav"); !ok || enc != EncodingPCM {
+		t.Errorf("Accept audio/pcm resultou em %q", enc)
+	}
+}
@@ -0,0 +1,82 @@
+package audio
+
+// Tabelas de segmentos do G.711 (ITU-T), como na implementação de
+// referência da Sun
+var (
+	mulawSegmentEnd = [8]int{0x3f, 0x7f, 0xff, 0x1ff, 0x3ff, 0x7ff, 0xfff, 0x1fff}
+	alawSegmentEnd  = [8]int{0x1f, 0x3f, 0x7f, 0xff, 0x1ff, 0x3ff, 0x7ff, 0xfff}
+)
+
+const (
+	mulawBias = 0x84 >> 2
+	mulawClip = 8159
+)
+
+// EncodeMulaw converte amostras PCM 16 bits em G.711 μ-law
+func EncodeMulaw(samples []int16) []byte {
+	out := make([]byte, len(samples))
+	for i, s := range samples {
+		out[i] = linearToMulaw(s)
+	}
+	return out
+}
+
+// EncodeAlaw converte amostras PCM 16 bits em G.711 A-law
+func EncodeAlaw(samples []int16) []byte {
+	out := make([]byte, len(samples))
+	for i, s := range samples {
+		out[i] = linearToAlaw(s)
+	}
+	return out
+}
+
+func linearToMulaw(sample int16) byte {
+	pcm := int(sample) >> 2
+	mask := 0xff
+	if pcm < 0 {
+		pcm = -pcm
+		mask = 0x7f
+	}
+	if pcm > mulawClip {
+		pcm = mulawClip
+	}
+	pcm += mulawBias
+
+	seg := segment(pcm, mulawSegmentEnd)
+	if seg >= 8 {
+		return byte(0x7f ^ mask)
+	}
+	return byte(((seg << 4) | ((pcm >> (seg + 1)) & 0x0f)) ^ mask)
+}
+
+func linearToAlaw(sample int16) byte {
+	pcm := int(sample) >> 3
+	mask := 0xd5
+	if pcm < 0 {
+		pcm = -pcm - 1
+		mask = 0x55
+	}
+
+	seg := segment(pcm, alawSegmentEnd)
+	if seg >= 8 {
+		return byte(0x7f ^ mask)
+	}
+
+	aval := seg << 4
+	if seg < 2 {
+		aval |= (pcm >> 1) & 0x0f
+	} else {
+		aval |= (pcm >> seg) & 0x0f
+	}
+	return byte(aval ^ mask)
+}
+
+// segment retorna o primeiro segmento cujo limite comporta o valor
+func segment(value int, ends [8]int) int {
+	for i, end := range ends {
+		if value <= end {
+			return i
+		}
+	}
+	return len(ends)
+}
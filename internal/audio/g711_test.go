@@ -0,0 +1,86 @@
+package audio
+
+import (
+	"math"
+	"testing"
+)
+
+// mulawToLinear e alawToLinear são os decodificadores da implementação de
+// referência da Sun, usados para conferir a codificação
+func mulawToLinear(u byte) int16 {
+	u = ^u
+	t := (int(u&0x0f) << 3) + 0x84
+	t <<= (u & 0x70) >> 4
+	if u&0x80 != 0 {
+		return int16(0x84 - t)
+	}
+	return int16(t - 0x84)
+}
+
+func alawToLinear(a byte) int16 {
+	a ^= 0x55
+	t := int(a&0x0f) << 4
+	switch seg := (a & 0x70) >> 4; seg {
+	case 0:
+		t += 8
+	case 1:
+		t += 0x108
+	default:
+		t += 0x108
+		t <<= seg - 1
+	}
+	if a&0x80 != 0 {
+		return int16(t)
+	}
+	return int16(-t)
+}
+
+func TestG711KnownValues(t *testing.T) {
+	tests := []struct {
+		sample      int16
+		mulaw, alaw byte
+	}{
+		{0, 0xff, 0xd5},
+		{-1, 0x7e, 0x55},
+		{math.MaxInt16, 0x80, 0xaa},
+		{math.MinInt16, 0x00, 0x2a},
+		{1000, 0xce, 0xfa},
+		{-1000, 0x4e, 0x7a},
+	}
+	for _, tt := range tests {
+		if got := EncodeMulaw([]int16{tt.sample})[0]; got != tt.mulaw {
+			t.Errorf("μ-law(%d) = %#02x, esperado %#02x", tt.sample, got, tt.mulaw)
+		}
+		if got := EncodeAlaw([]int16{tt.sample})[0]; got != tt.alaw {
+			t.Errorf("A-law(%d) = %#02x, esperado %#02x", tt.sample, got, tt.alaw)
+		}
+	}
+}
+
+func TestG711RoundTrip(t *testing.T) {
+	codecs := []struct {
+		name   string
+		encode func([]int16) []byte
+		decode func(byte) int16
+	}{
+		{"μ-law", EncodeMulaw, mulawToLinear},
+		{"A-law", EncodeAlaw, alawToLinear},
+	}
+
+	for _, codec := range codecs {
+		previous := int16(math.MinInt16)
+		for s := math.MinInt16; s <= math.MaxInt16; s++ {
+			got := codec.decode(codec.encode([]int16{int16(s)})[0])
+
+			// O erro de quantização cresce com a amplitude: o passo é de
+			// 1/16 do início do segmento
+			if diff := math.Abs(float64(got) - float64(s)); diff > 64+math.Abs(float64(s))/16 {
+				t.Fatalf("%s: %d decodificado como %d", codec.name, s, got)
+			}
+			if got < previous {
+				t.Fatalf("%s: decodificação não monotônica em %d (%d < %d)", codec.name, s, got, previous)
+			}
+			previous = got
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MP3Bitrates são os bitrates CBR aceitos para MP3 mono, em bits por segundo
+var MP3Bitrates = []int{32000, 40000, 48000, 56000, 64000, 80000, 96000, 112000, 128000, 160000, 192000}
+
+// mp3LSFMaxBitrate é o maior bitrate do MPEG-2 e do MPEG-2.5, usados nas
+// taxas abaixo de 32 kHz
+const mp3LSFMaxBitrate = 160000
+
+// MP3MaxBitrate retorna o maior bitrate CBR aceito na taxa de amostragem
+func MP3MaxBitrate(sampleRate int) int {
+	if sampleRate < 32000 {
+		return mp3LSFMaxBitrate
+	}
+	return MP3Bitrates[len(MP3Bitrates)-1]
+}
+
+// mp3Encoder é implementado pela ligação com o LAME (build tag "mp3")
+type mp3Encoder interface {
+	// Encode codifica amostras mono e retorna os quadros completos gerados
+	Encode(pcm []int16) ([]byte, error)
+	// Flush finaliza o fluxo e retorna os quadros restantes
+	Flush() ([]byte, error)
+	Close()
+}
+
+// MP3Options configura a codificação MP3
+type MP3Options struct {
+	Bitrate int // bits por segundo, CBR
+}
+
+// Validate verifica se o bitrate é um dos valores CBR suportados
+func (o MP3Options) Validate() error {
+	for _, bitrate := range MP3Bitrates {
+		if bitrate == o.Bitrate {
+			return nil
+		}
+	}
+	return fmt.Errorf("bitrate mp3 deve ser um de %v bps", MP3Bitrates)
+}
+
+// ValidateSampleRate verifica se o bitrate é aceito na taxa de saída, já
+// que o LAME o reduziria sem aviso
+func (o MP3Options) ValidateSampleRate(sampleRate int) error {
+	if limit := MP3MaxBitrate(sampleRate); o.Bitrate > limit {
+		return fmt.Errorf("bitrate mp3 a %d Hz deve ser no máximo %d bps", sampleRate, limit)
+	}
+	return nil
+}
+
+// EncodeMP3 codifica o áudio em MP3 mono com bitrate constante
+func EncodeMP3(p *PCM, opts MP3Options) ([]byte, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	pcm := p.Mono()
+	if err := opts.ValidateSampleRate(pcm.SampleRate); err != nil {
+		return nil, err
+	}
+	enc, err := newMP3Encoder(pcm.SampleRate, opts.Bitrate)
+	if err != nil {
+		return nil, err
+	}
+	defer enc.Close()
+
+	out, err := enc.Encode(pcm.Samples)
+	if err != nil {
+		return nil, err
+	}
+	tail, err := enc.Flush()
+	if err != nil {
+		return nil, err
+	}
+	return append(out, tail...), nil
+}
+
+// Tabelas do cabeçalho de quadro MPEG áudio Layer III
+var (
+	mp3BitratesV1  = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0}
+	mp3BitratesV2  = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0}
+	mp3SampleRates = [4]int{44100, 48000, 32000, 0}
+)
+
+// MP3Duration soma a duração dos quadros de um fluxo MP3 Layer III,
+// ignorando tags ID3v2 e o quadro de informações Xing/Info
+func MP3Duration(data []byte) (float64, error) {
+	// Ignora a tag ID3v2, se houver
+	if len(data) >= 10 && string(data[:3]) == "ID3" {
+		size := int(data[6]&0x7f)<<21 | int(data[7]&0x7f)<<14 | int(data[8]&0x7f)<<7 | int(data[9]&0x7f)
+		if 10+size > len(data) {
+			return 0, errors.New("tag ID3 truncada")
+		}
+		data = data[10+size:]
+	}
+
+	var duration float64
+	frames := 0
+	for len(data) >= 4 {
+		if data[0] != 0xff || data[1]&0xe0 != 0xe0 {
+			return 0, fmt.Errorf("cabeçalho de quadro MP3 inválido após %d quadros", frames)
+		}
+
+		version := (data[1] >> 3) & 0x03 // 0 = MPEG 2.5, 2 = MPEG 2, 3 = MPEG 1
+		layer := (data[1] >> 1) & 0x03   // 1 = Layer III
+		bitrateIndex := data[2] >> 4
+		rateIndex := (data[2] >> 2) & 0x03
+		padding := int(data[2]>>1) & 0x01
+
+		if version == 1 || layer != 1 {
+			return 0, errors.New("apenas MPEG Layer III é suportado")
+		}
+
+		sampleRate := mp3SampleRates[rateIndex]
+		bitrate := mp3BitratesV1[bitrateIndex]
+		samplesPerFrame, coefficient := 1152, 144
+		if version != 3 {
+			bitrate = mp3BitratesV2[bitrateIndex]
+			samplesPerFrame, coefficient = 576, 72
+			sampleRate /= 2
+			if version == 0 {
+				sampleRate /= 2
+			}
+		}
+		if sampleRate == 0 || bitrate == 0 {
+			return 0, errors.New("cabeçalho de quadro MP3 inválido")
+		}
+
+		frameSize := coefficient*bitrate*1000/sampleRate + padding
+		if frameSize > len(data) {
+			break // quadro final truncado
+		}
+		if frames > 0 || !isXingFrame(data[:frameSize]) {
+			duration += float64(samplesPerFrame) / float64(sampleRate)
+		}
+
+		frames++
+		data = data[frameSize:]
+	}
+
+	if frames == 0 {
+		return 0, errors.New("nenhum quadro MP3 encontrado")
+	}
+	return duration, nil
+}
+
+// isXingFrame identifica o quadro de metadados gravado por encoders VBR
+func isXingFrame(frame []byte) bool {
+	for _, tag := range []string{"Xing", "Info"} {
+		for i := 4; i+len(tag) <= len(frame) && i < 40; i++ {
+			if string(frame[i:i+len(tag)]) == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+//go:build mp3
+
+package audio
+
+/*
+#cgo LDFLAGS: -lmp3lame
+#include <lame/lame.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// MP3Available indica se o binário foi compilado com suporte ao LAME
+const MP3Available = true
+
+type lameEncoder struct {
+	gfp C.lame_t
+}
+
+func newMP3Encoder(sampleRate, bitrate int) (mp3Encoder, error) {
+	gfp := C.lame_init()
+	if gfp == nil {
+		return nil, errors.New("erro ao criar encoder mp3")
+	}
+
+	C.lame_set_in_samplerate(gfp, C.int(sampleRate))
+	C.lame_set_num_channels(gfp, 1)
+	C.lame_set_mode(gfp, C.MONO)
+	C.lame_set_VBR(gfp, C.vbr_off)
+	C.lame_set_brate(gfp, C.int(bitrate/1000))
+	C.lame_set_quality(gfp, 2)
+	C.lame_set_bWriteVbrTag(gfp, 0)
+
+	if ret := C.lame_init_params(gfp); ret < 0 {
+		C.lame_close(gfp)
+		return nil, fmt.Errorf("erro ao configurar encoder mp3 (código %d)", int(ret))
+	}
+	return &lameEncoder{gfp: gfp}, nil
+}
+
+func (e *lameEncoder) Encode(pcm []int16) ([]byte, error) {
+	if len(pcm) == 0 {
+		return nil, nil
+	}
+
+	// Tamanho recomendado pela documentação do LAME
+	out := make([]byte, len(pcm)*5/4+7200)
+	n := C.lame_encode_buffer(e.gfp,
+		(*C.short)(unsafe.Pointer(&pcm[0])),
+		nil,
+		C.int(len(pcm)),
+		(*C.uchar)(unsafe.Pointer(&out[0])),
+		C.int(len(out)),
+	)
+	if n < 0 {
+		return nil, fmt.Errorf("erro na codificação mp3 (código %d)", int(n))
+	}
+	return out[:n], nil
+}
+
+func (e *lameEncoder) Flush() ([]byte, error) {
+	out := make([]byte, 7200)
+	n := C.lame_encode_flush(e.gfp, (*C.uchar)(unsafe.Pointer(&out[0])), C.int(len(out)))
+	if n < 0 {
+		return nil, fmt.Errorf("erro ao finalizar mp3 (código %d)", int(n))
+	}
+	return out[:n], nil
+}
+
+func (e *lameEncoder) Close() {
+	C.lame_close(e.gfp)
+}
@@ -0,0 +1,10 @@
+//go:build !mp3
+
+package audio
+
+// MP3Available indica se o binário foi compilado com suporte ao LAME
+const MP3Available = false
+
+func newMP3Encoder(sampleRate, bitrate int) (mp3Encoder, error) {
+	return nil, ErrCodecUnavailable
+}
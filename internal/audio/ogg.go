@@ -0,0 +1,170 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	oggHeaderTypeBOS = 0x02
+	oggHeaderTypeEOS = 0x04
+
+	// oggMaxSegments é o limite de segmentos (lacing values) por página
+	oggMaxSegments = 255
+)
+
+var oggCRCTable = func() [256]uint32 {
+	var table [256]uint32
+	for i := range table {
+		r := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if r&0x80000000 != 0 {
+				r = (r << 1) ^ 0x04c11db7
+			} else {
+				r <<= 1
+			}
+		}
+		table[i] = r
+	}
+	return table
+}()
+
+func oggCRC(data []byte) uint32 {
+	var crc uint32
+	for _, b := range data {
+		crc = (crc << 8) ^ oggCRCTable[byte(crc>>24)^b]
+	}
+	return crc
+}
+
+// oggWriter agrupa pacotes em páginas de um único fluxo lógico Ogg
+type oggWriter struct {
+	buf      bytes.Buffer
+	serial   uint32
+	sequence uint32
+	started  bool
+
+	segments []byte
+	payload  bytes.Buffer
+	granule  int64
+}
+
+func newOggWriter(serial uint32) *oggWriter {
+	return &oggWriter{serial: serial}
+}
+
+// WritePacket acrescenta um pacote à página atual; granule é a posição ao
+// final do pacote. A página é fechada quando não couber mais segmentos.
+func (w *oggWriter) WritePacket(packet []byte, granule int64) {
+	needed := len(packet)/255 + 1
+	if len(w.segments)+needed > oggMaxSegments {
+		w.Flush(false)
+	}
+
+	for n := len(packet); ; n -= 255 {
+		if n < 255 {
+			w.segments = append(w.segments, byte(n))
+			break
+		}
+		w.segments = append(w.segments, 255)
+	}
+	w.payload.Write(packet)
+	w.granule = granule
+}
+
+// Flush grava a página pendente; eos marca o fim do fluxo lógico
+func (w *oggWriter) Flush(eos bool) {
+	if len(w.segments) == 0 && !eos {
+		return
+	}
+
+	var headerType byte
+	if !w.started {
+		headerType |= oggHeaderTypeBOS
+		w.started = true
+	}
+	if eos {
+		headerType |= oggHeaderTypeEOS
+	}
+
+	page := make([]byte, 27, 27+len(w.segments)+w.payload.Len())
+	copy(page, "OggS")
+	page[4] = 0 // versão
+	page[5] = headerType
+	binary.LittleEndian.PutUint64(page[6:], uint64(w.granule))
+	binary.LittleEndian.PutUint32(page[14:], w.serial)
+	binary.LittleEndian.PutUint32(page[18:], w.sequence)
+	page[26] = byte(len(w.segments))
+	page = append(page, w.segments...)
+	page = append(page, w.payload.Bytes()...)
+	binary.LittleEndian.PutUint32(page[22:], oggCRC(page))
+
+	w.buf.Write(page)
+	w.sequence++
+	w.segments = w.segments[:0]
+	w.payload.Reset()
+}
+
+// Bytes retorna o fluxo Ogg gerado até o momento
+func (w *oggWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// oggPage é o cabeçalho de uma página lida de um fluxo Ogg
+type oggPage struct {
+	headerType byte
+	granule    int64
+	serial     uint32
+	packets    [][]byte // pacotes completos que terminam nesta página
+}
+
+// readOggPages percorre as páginas do fluxo validando o CRC de cada uma
+func readOggPages(data []byte) ([]oggPage, error) {
+	var pages []oggPage
+	var partial []byte
+
+	for len(data) > 0 {
+		if len(data) < 27 || string(data[:4]) != "OggS" {
+			return nil, errors.New("página Ogg inválida")
+		}
+		numSegments := int(data[26])
+		if len(data) < 27+numSegments {
+			return nil, errors.New("página Ogg truncada")
+		}
+		lacing := data[27 : 27+numSegments]
+		size := 27 + numSegments
+		for _, l := range lacing {
+			size += int(l)
+		}
+		if len(data) < size {
+			return nil, errors.New("página Ogg truncada")
+		}
+
+		page := append([]byte(nil), data[:size]...)
+		binary.LittleEndian.PutUint32(page[22:], 0)
+		if oggCRC(page) != binary.LittleEndian.Uint32(data[22:26]) {
+			return nil, errors.New("CRC inválido em página Ogg")
+		}
+
+		p := oggPage{
+			headerType: data[5],
+			granule:    int64(binary.LittleEndian.Uint64(data[6:14])),
+			serial:     binary.LittleEndian.Uint32(data[14:18]),
+		}
+		body := data[27+numSegments : size]
+		for _, l := range lacing {
+			partial = append(partial, body[:l]...)
+			body = body[l:]
+			if l < 255 {
+				p.packets = append(p.packets, partial)
+				partial = nil
+			}
+		}
+
+		pages = append(pages, p)
+		data = data[size:]
+	}
+
+	return pages, nil
+}
@@ -0,0 +1,178 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+const (
+	// opusGranuleRate é a taxa usada nas posições de granule do Ogg Opus,
+	// independente da taxa do encoder
+	opusGranuleRate = 48000
+	// opusFrameMillis é a duração de cada pacote Opus gerado
+	opusFrameMillis = 20
+	// opusMaxPacket é o tamanho máximo recomendado para um pacote Opus
+	opusMaxPacket = 4000
+)
+
+// Intervalo de bitrate aceito pelo libopus, em bits por segundo
+const (
+	OpusMinBitrate = 6000
+	OpusMaxBitrate = 510000
+)
+
+// OpusSampleRates são as taxas de amostragem suportadas pelo encoder Opus
+var OpusSampleRates = []int{8000, 12000, 16000, 24000, 48000}
+
+// opusEncoder é implementado pela ligação com o libopus (build tag "opus")
+type opusEncoder interface {
+	// Encode codifica um quadro completo e retorna o tamanho do pacote
+	Encode(pcm []int16, packet []byte) (int, error)
+	// Lookahead retorna o atraso do encoder em amostras
+	Lookahead() (int, error)
+	Close()
+}
+
+// OpusOptions configura a codificação Ogg Opus
+type OpusOptions struct {
+	Bitrate    int // bits por segundo
+	SampleRate int // uma das taxas em OpusSampleRates
+}
+
+// Validate verifica se as opções são suportadas pelo encoder
+func (o OpusOptions) Validate() error {
+	if o.Bitrate < OpusMinBitrate || o.Bitrate > OpusMaxBitrate {
+		return fmt.Errorf("bitrate opus deve estar entre %d e %d bps", OpusMinBitrate, OpusMaxBitrate)
+	}
+	for _, rate := range OpusSampleRates {
+		if rate == o.SampleRate {
+			return nil
+		}
+	}
+	return fmt.Errorf("taxa de amostragem opus deve ser uma de %v", OpusSampleRates)
+}
+
+// EncodeOggOpus codifica o áudio em Opus dentro de um contêiner Ogg
+// (RFC 7845), reamostrando para a taxa configurada
+func EncodeOggOpus(p *PCM, opts OpusOptions) ([]byte, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if p.Channels < 1 || p.Channels > 2 {
+		return nil, fmt.Errorf("opus suporta apenas áudio mono ou estéreo")
+	}
+
+	pcm := Resample(p, opts.SampleRate)
+	enc, err := newOpusEncoder(pcm.SampleRate, pcm.Channels, opts.Bitrate)
+	if err != nil {
+		return nil, err
+	}
+	defer enc.Close()
+
+	lookahead, err := enc.Lookahead()
+	if err != nil {
+		return nil, err
+	}
+
+	scale := opusGranuleRate / pcm.SampleRate
+	preSkip := lookahead * scale
+	frameSize := pcm.SampleRate * opusFrameMillis / 1000
+	totalFrames := pcm.Frames()
+
+	// Completa com silêncio para compensar o atraso do encoder e fechar o
+	// último quadro
+	samples := append([]int16(nil), pcm.Samples...)
+	padded := totalFrames + lookahead
+	if rem := padded % frameSize; rem != 0 {
+		padded += frameSize - rem
+	}
+	samples = append(samples, make([]int16, (padded-totalFrames)*pcm.Channels)...)
+
+	ogg := newOggWriter(crc32.ChecksumIEEE(pcmBytes(pcm.Samples)))
+	ogg.WritePacket(opusHead(pcm.Channels, preSkip, p.SampleRate), 0)
+	ogg.Flush(false)
+	ogg.WritePacket(opusTags(), 0)
+	ogg.Flush(false)
+
+	finalGranule := int64(preSkip + totalFrames*scale)
+	packet := make([]byte, opusMaxPacket)
+	var granule int64
+	for offset := 0; offset < padded; offset += frameSize {
+		frame := samples[offset*pcm.Channels : (offset+frameSize)*pcm.Channels]
+		n, err := enc.Encode(frame, packet)
+		if err != nil {
+			return nil, err
+		}
+
+		granule += int64(frameSize * scale)
+		if offset+frameSize >= padded {
+			// A última página informa a posição real para descartar o preenchimento
+			granule = finalGranule
+		}
+		ogg.WritePacket(packet[:n], granule)
+	}
+	ogg.Flush(true)
+
+	return ogg.Bytes(), nil
+}
+
+// OggOpusDuration calcula a duração de um fluxo Ogg Opus a partir do
+// pre-skip do cabeçalho e da posição de granule da última página
+func OggOpusDuration(data []byte) (float64, error) {
+	pages, err := readOggPages(data)
+	if err != nil {
+		return 0, err
+	}
+	if len(pages) == 0 || len(pages[0].packets) == 0 {
+		return 0, errors.New("fluxo Ogg vazio")
+	}
+
+	head := pages[0].packets[0]
+	if len(head) < 19 || !bytes.HasPrefix(head, []byte("OpusHead")) {
+		return 0, errors.New("fluxo Ogg não contém Opus")
+	}
+	preSkip := int64(binary.LittleEndian.Uint16(head[10:12]))
+
+	last := pages[len(pages)-1].granule
+	if last < preSkip {
+		return 0, nil
+	}
+	return float64(last-preSkip) / opusGranuleRate, nil
+}
+
+// opusHead monta o cabeçalho de identificação (RFC 7845, seção 5.1)
+func opusHead(channels, preSkip, inputRate int) []byte {
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	head[8] = 1 // versão
+	head[9] = byte(channels)
+	binary.LittleEndian.PutUint16(head[10:], uint16(preSkip))
+	binary.LittleEndian.PutUint32(head[12:], uint32(inputRate))
+	binary.LittleEndian.PutUint16(head[16:], 0) // ganho de saída
+	head[18] = 0                                // mapeamento de canais: mono/estéreo
+	return head
+}
+
+// opusTags monta o cabeçalho de comentários (RFC 7845, seção 5.2)
+func opusTags() []byte {
+	const vendor = "GoTTS"
+	tags := make([]byte, 0, 8+4+len(vendor)+4)
+	tags = append(tags, "OpusTags"...)
+	tags = binary.LittleEndian.AppendUint32(tags, uint32(len(vendor)))
+	tags = append(tags, vendor...)
+	tags = binary.LittleEndian.AppendUint32(tags, 0) // nenhum comentário
+	return tags
+}
+
+// pcmBytes serializa as amostras em little-endian
+func pcmBytes(samples []int16) []byte {
+	out := make([]byte, len(samples)*2)
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(out[i*2:], uint16(s))
+	}
+	return out
+}
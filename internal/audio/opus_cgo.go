@@ -0,0 +1,71 @@
+//go:build opus
+
+package audio
+
+/*
+#cgo pkg-config: opus
+#include <opus.h>
+
+// opus_encoder_ctl é variádica e não pode ser chamada diretamente pelo cgo
+static int gotts_opus_set_bitrate(OpusEncoder *enc, opus_int32 bitrate) {
+	return opus_encoder_ctl(enc, OPUS_SET_BITRATE(bitrate));
+}
+
+static int gotts_opus_get_lookahead(OpusEncoder *enc, opus_int32 *lookahead) {
+	return opus_encoder_ctl(enc, OPUS_GET_LOOKAHEAD(lookahead));
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// OpusAvailable indica se o binário foi compilado com suporte ao libopus
+const OpusAvailable = true
+
+type libopusEncoder struct {
+	enc      *C.OpusEncoder
+	channels int
+}
+
+func newOpusEncoder(sampleRate, channels, bitrate int) (opusEncoder, error) {
+	var cerr C.int
+	enc := C.opus_encoder_create(C.opus_int32(sampleRate), C.int(channels), C.OPUS_APPLICATION_VOIP, &cerr)
+	if cerr != C.OPUS_OK {
+		return nil, fmt.Errorf("erro ao criar encoder opus: %s", C.GoString(C.opus_strerror(cerr)))
+	}
+
+	if ret := C.gotts_opus_set_bitrate(enc, C.opus_int32(bitrate)); ret != C.OPUS_OK {
+		C.opus_encoder_destroy(enc)
+		return nil, fmt.Errorf("erro ao configurar bitrate opus: %s", C.GoString(C.opus_strerror(ret)))
+	}
+
+	return &libopusEncoder{enc: enc, channels: channels}, nil
+}
+
+func (e *libopusEncoder) Encode(pcm []int16, packet []byte) (int, error) {
+	n := C.opus_encode(e.enc,
+		(*C.opus_int16)(unsafe.Pointer(&pcm[0])),
+		C.int(len(pcm)/e.channels),
+		(*C.uchar)(unsafe.Pointer(&packet[0])),
+		C.opus_int32(len(packet)),
+	)
+	if n < 0 {
+		return 0, fmt.Errorf("erro na codificação opus: %s", C.GoString(C.opus_strerror(n)))
+	}
+	return int(n), nil
+}
+
+func (e *libopusEncoder) Lookahead() (int, error) {
+	var lookahead C.opus_int32
+	if ret := C.gotts_opus_get_lookahead(e.enc, &lookahead); ret != C.OPUS_OK {
+		return 0, fmt.Errorf("erro ao consultar atraso do encoder opus: %s", C.GoString(C.opus_strerror(ret)))
+	}
+	return int(lookahead), nil
+}
+
+func (e *libopusEncoder) Close() {
+	C.opus_encoder_destroy(e.enc)
+}
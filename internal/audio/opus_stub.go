@@ -0,0 +1,10 @@
+//go:build !opus
+
+package audio
+
+// OpusAvailable indica se o binário foi compilado com suporte ao libopus
+const OpusAvailable = false
+
+func newOpusEncoder(sampleRate, channels, bitrate int) (opusEncoder, error) {
+	return nil, ErrCodecUnavailable
+}
@@ -0,0 +1,6 @@
+package audio
+
+import "tts-api/internal/audio/wav"
+
+// PCM é um áudio PCM 16 bits com amostras intercaladas por canal
+type PCM = wav.PCM
@@ -0,0 +1,121 @@
+package audio
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	// resampleZeroCrossings é a quantidade de cruzamentos por zero do sinc
+	// usados em cada lado do filtro; define a largura da banda de transição
+	resampleZeroCrossings = 16
+	// resamplePhases é a resolução da tabela do filtro por cruzamento
+	resamplePhases = 512
+	// resampleKaiserBeta controla a atenuação da banda de rejeição (~90 dB)
+	resampleKaiserBeta = 8.6
+	// resampleRolloff afasta o corte da frequência de Nyquist para reduzir aliasing
+	resampleRolloff = 0.945
+)
+
+var (
+	resampleTableOnce sync.Once
+	resampleTable     []float64
+)
+
+// Resample converte o áudio para a taxa de amostragem informada usando
+// interpolação por sinc janelado (Kaiser). Na redução de taxa o filtro é
+// alargado para atuar como passa-baixas antes da decimação.
+func Resample(p *PCM, sampleRate int) *PCM {
+	if p.SampleRate == sampleRate || p.Frames() == 0 || sampleRate <= 0 {
+		return &PCM{SampleRate: sampleRate, Channels: p.Channels, Samples: append([]int16(nil), p.Samples...)}
+	}
+
+	resampleTableOnce.Do(buildResampleTable)
+
+	ratio := float64(sampleRate) / float64(p.SampleRate)
+	cutoff := math.Min(1, ratio) * resampleRolloff
+	halfWidth := float64(resampleZeroCrossings) / cutoff
+
+	inFrames := p.Frames()
+	outFrames := int(math.Round(float64(inFrames) * ratio))
+	channels := p.Channels
+	out := make([]int16, outFrames*channels)
+
+	for n := 0; n < outFrames; n++ {
+		center := float64(n) / ratio
+		first := int(math.Ceil(center - halfWidth))
+		last := int(math.Floor(center + halfWidth))
+		if first < 0 {
+			first = 0
+		}
+		if last >= inFrames {
+			last = inFrames - 1
+		}
+
+		for c := 0; c < channels; c++ {
+			var acc float64
+			for k := first; k <= last; k++ {
+				acc += float64(p.Samples[k*channels+c]) * resampleKernel((center-float64(k))*cutoff)
+			}
+			out[n*channels+c] = clampInt16(acc * cutoff)
+		}
+	}
+
+	return &PCM{SampleRate: sampleRate, Channels: channels, Samples: out}
+}
+
+// resampleKernel retorna o valor do sinc janelado em x (em cruzamentos por
+// zero), interpolando linearmente a tabela pré-calculada
+func resampleKernel(x float64) float64 {
+	x = math.Abs(x)
+	pos := x * resamplePhases
+	i := int(pos)
+	if i >= len(resampleTable)-1 {
+		return 0
+	}
+	frac := pos - float64(i)
+	return resampleTable[i] + frac*(resampleTable[i+1]-resampleTable[i])
+}
+
+func buildResampleTable() {
+	size := resampleZeroCrossings*resamplePhases + 1
+	resampleTable = make([]float64, size+1)
+	norm := besselI0(resampleKaiserBeta)
+	for i := 0; i < size; i++ {
+		x := float64(i) / resamplePhases
+		r := x / resampleZeroCrossings
+		window := besselI0(resampleKaiserBeta*math.Sqrt(1-r*r)) / norm
+		resampleTable[i] = sinc(x) * window
+	}
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// besselI0 calcula a função de Bessel modificada de ordem zero por série
+func besselI0(x float64) float64 {
+	sum, term := 1.0, 1.0
+	for k := 1; k < 50; k++ {
+		term *= (x / (2 * float64(k))) * (x / (2 * float64(k)))
+		sum += term
+		if term < 1e-12*sum {
+			break
+		}
+	}
+	return sum
+}
+
+func clampInt16(v float64) int16 {
+	v = math.Round(v)
+	if v > math.MaxInt16 {
+		return math.MaxInt16
+	}
+	if v < math.MinInt16 {
+		return math.MinInt16
+	}
+	return int16(v)
+}
@@ -0,0 +1,97 @@
+package audio
+
+import (
+	"math"
+	"testing"
+)
+
+// tone gera um seno mono com a frequência e a amplitude informadas
+func tone(sampleRate int, freq, amplitude, seconds float64) *PCM {
+	samples := make([]int16, int(float64(sampleRate)*seconds))
+	for i := range samples {
+		samples[i] = int16(amplitude * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)))
+	}
+	return &PCM{SampleRate: sampleRate, Channels: 1, Samples: samples}
+}
+
+// rms calcula o valor eficaz ignorando as bordas, onde o filtro tem menos
+// amostras de entrada
+func rms(samples []int16) float64 {
+	edge := len(samples) / 10
+	var sum float64
+	for _, s := range samples[edge : len(samples)-edge] {
+		sum += float64(s) * float64(s)
+	}
+	return math.Sqrt(sum / float64(len(samples)-2*edge))
+}
+
+// crossings conta as passagens de negativo para não negativo
+func crossings(samples []int16) int {
+	n := 0
+	for i := 1; i < len(samples); i++ {
+		if samples[i-1] < 0 && samples[i] >= 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func TestResampleTone(t *testing.T) {
+	tests := []struct {
+		from, to int
+	}{
+		{22050, 16000},
+		{22050, 8000},
+		{16000, 48000},
+		{22050, 44100},
+	}
+	for _, tt := range tests {
+		in := tone(tt.from, 440, 10000, 1)
+		out := Resample(in, tt.to)
+
+		if out.SampleRate != tt.to || out.Channels != 1 {
+			t.Fatalf("%d→%d: formato %d Hz, %d canais", tt.from, tt.to, out.SampleRate, out.Channels)
+		}
+		if len(out.Samples) != tt.to {
+			t.Errorf("%d→%d: %d amostras, esperado %d", tt.from, tt.to, len(out.Samples), tt.to)
+		}
+		// A amplitude e a frequência de um tom na banda passante se mantêm
+		if ratio := rms(out.Samples) / rms(in.Samples); math.Abs(ratio-1) > 0.02 {
+			t.Errorf("%d→%d: ganho %.3f", tt.from, tt.to, ratio)
+		}
+		if n := crossings(out.Samples); n < 439 || n > 441 {
+			t.Errorf("%d→%d: %d ciclos, esperado 440", tt.from, tt.to, n)
+		}
+	}
+}
+
+func TestResampleAntiAliasing(t *testing.T) {
+	// 6 kHz fica acima da frequência de Nyquist de 8 kHz e deve ser filtrado
+	in := tone(22050, 6000, 10000, 1)
+	out := Resample(in, 8000)
+	if ratio := rms(out.Samples) / rms(in.Samples); ratio > 0.01 {
+		t.Errorf("tom acima de Nyquist atenuado apenas para %.3f", ratio)
+	}
+}
+
+func TestResampleStereoAndSameRate(t *testing.T) {
+	in := &PCM{SampleRate: 22050, Channels: 2, Samples: make([]int16, 2*2205)}
+	for i := 0; i < len(in.Samples); i += 2 {
+		in.Samples[i] = 1000 // canal esquerdo constante, direito em silêncio
+	}
+
+	out := Resample(in, 11025)
+	if out.Channels != 2 || len(out.Samples) != 2*1103 {
+		t.Fatalf("%d canais e %d amostras", out.Channels, len(out.Samples))
+	}
+	mid := len(out.Samples) / 2 &^ 1
+	if left, right := out.Samples[mid], out.Samples[mid+1]; math.Abs(float64(left)-1000) > 10 || right != 0 {
+		t.Errorf("canais misturados: esquerdo %d, direito %d", left, right)
+	}
+
+	same := Resample(in, 22050)
+	same.Samples[0] = 0
+	if in.Samples[0] != 1000 {
+		t.Error("Resample na mesma taxa não copiou as amostras")
+	}
+}
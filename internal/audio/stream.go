@@ -0,0 +1,75 @@
+package audio
+
+import (
+	"fmt"
+	"tts-api/internal/audio/wav"
+)
+
+// StreamEncoder codifica o áudio em partes, à medida que cada trecho é
+// sintetizado. Apenas formatos sem estado entre quadros são suportados.
+type StreamEncoder struct {
+	encoding   Encoding
+	opts       Options
+	sampleRate int
+	channels   int
+	started    bool
+}
+
+// NewStreamEncoder cria um codificador contínuo para o formato informado
+func NewStreamEncoder(enc Encoding, opts Options) (*StreamEncoder, error) {
+	switch enc {
+	case EncodingWAV, EncodingPCM, EncodingMulaw, EncodingAlaw:
+	default:
+		return nil, fmt.Errorf("encoding %s não suporta streaming (use wav, pcm_s16le, mulaw ou alaw)", enc)
+	}
+	if err := opts.Validate(enc); err != nil {
+		return nil, err
+	}
+	return &StreamEncoder{encoding: enc, opts: opts}, nil
+}
+
+// Encode converte o WAV de um trecho e retorna os bytes a transmitir e a
+// duração do trecho. A primeira chamada define a taxa de saída e, para WAV,
+// inclui o cabeçalho de streaming.
+func (s *StreamEncoder) Encode(data []byte) ([]byte, float64, error) {
+	pcm, err := wav.Decode(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var out []byte
+	if !s.started {
+		s.sampleRate = OutputSampleRate(s.encoding, s.opts, pcm.SampleRate)
+		s.channels = pcm.Channels
+		if s.encoding == EncodingWAV {
+			out = wav.StreamHeader(s.sampleRate, s.channels)
+		}
+		s.started = true
+	}
+	if pcm.Channels != s.channels {
+		return nil, 0, fmt.Errorf("quantidade de canais mudou durante o streaming")
+	}
+	if pcm.SampleRate != s.sampleRate {
+		pcm = Resample(pcm, s.sampleRate)
+	}
+
+	return append(out, encodeRaw(pcm, s.encoding)...), pcm.Duration(), nil
+}
+
+// MimeType retorna o Content-Type do fluxo; válido após o primeiro Encode
+func (s *StreamEncoder) MimeType() string {
+	if s.encoding == EncodingWAV {
+		return s.encoding.MimeType()
+	}
+	return rawMimeType(s.encoding, s.sampleRate, s.channels)
+}
+
+// SampleRate retorna a taxa de saída; válido após o primeiro Encode
+func (s *StreamEncoder) SampleRate() int {
+	return s.sampleRate
+}
+
+// Codec retorna o nome do codec do fluxo
+func (s *StreamEncoder) Codec() string {
+	return s.encoding.Codec()
+}
@@ -0,0 +1,241 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Códigos de formato do chunk fmt
+const (
+	FormatPCM        uint16 = 0x0001
+	FormatIEEEFloat  uint16 = 0x0003
+	FormatExtensible uint16 = 0xfffe
+)
+
+// Erros retornados na leitura de arquivos malformados
+var (
+	ErrNotWAV            = errors.New("arquivo não é um WAV (RIFF/WAVE)")
+	ErrMissingFmt        = errors.New("chunk fmt ausente ou antes do chunk data")
+	ErrMissingData       = errors.New("chunk data ausente")
+	ErrInvalidFmt        = errors.New("chunk fmt inválido")
+	ErrUnsupportedFormat = errors.New("formato de áudio WAV não suportado")
+)
+
+// Header descreve o áudio contido em um arquivo WAV
+type Header struct {
+	Format        uint16 // FormatPCM ou FormatIEEEFloat (já resolvido para WAVE_FORMAT_EXTENSIBLE)
+	Channels      int
+	SampleRate    int
+	BitsPerSample int
+	BlockAlign    int
+	DataSize      int // bytes de áudio disponíveis, múltiplo de BlockAlign
+}
+
+// Frames retorna a quantidade de amostras por canal
+func (h Header) Frames() int {
+	return h.DataSize / h.BlockAlign
+}
+
+// Duration retorna a duração do áudio em segundos
+func (h Header) Duration() float64 {
+	return float64(h.Frames()) / float64(h.SampleRate)
+}
+
+// Parse percorre os chunks RIFF, valida os chunks fmt e data e retorna o
+// cabeçalho junto com os bytes de áudio. Chunks desconhecidos (LIST, fact,
+// ...) são ignorados. Um chunk data com tamanho maior que o arquivo, como nos
+// cabeçalhos de streaming, é limitado aos bytes disponíveis.
+func Parse(data []byte) (Header, []byte, error) {
+	var h Header
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return h, nil, ErrNotWAV
+	}
+
+	haveFmt := false
+	rest := data[12:]
+	for len(rest) >= 8 {
+		id := string(rest[0:4])
+		size := int64(binary.LittleEndian.Uint32(rest[4:8]))
+		body := rest[8:]
+
+		if id == "data" {
+			if !haveFmt {
+				return h, nil, ErrMissingFmt
+			}
+			if size > int64(len(body)) {
+				size = int64(len(body))
+			}
+			size -= size % int64(h.BlockAlign)
+			h.DataSize = int(size)
+			return h, body[:size], nil
+		}
+
+		if size > int64(len(body)) {
+			return h, nil, fmt.Errorf("chunk %q truncado", id)
+		}
+		if id == "fmt " {
+			var err error
+			if h, err = parseFmt(body[:size]); err != nil {
+				return h, nil, err
+			}
+			haveFmt = true
+		}
+
+		// Chunks de tamanho ímpar são seguidos por um byte de preenchimento
+		next := size + size%2
+		if next > int64(len(body)) {
+			next = int64(len(body))
+		}
+		rest = body[next:]
+	}
+
+	if !haveFmt {
+		return h, nil, ErrMissingFmt
+	}
+	return h, nil, ErrMissingData
+}
+
+func parseFmt(chunk []byte) (Header, error) {
+	var h Header
+	if len(chunk) < 16 {
+		return h, ErrInvalidFmt
+	}
+
+	h.Format = binary.LittleEndian.Uint16(chunk[0:2])
+	h.Channels = int(binary.LittleEndian.Uint16(chunk[2:4]))
+	h.SampleRate = int(binary.LittleEndian.Uint32(chunk[4:8]))
+	h.BlockAlign = int(binary.LittleEndian.Uint16(chunk[12:14]))
+	h.BitsPerSample = int(binary.LittleEndian.Uint16(chunk[14:16]))
+
+	if h.Format == FormatExtensible {
+		// WAVE_FORMAT_EXTENSIBLE: o formato real está nos dois primeiros
+		// bytes do GUID do subformato
+		if len(chunk) < 40 {
+			return h, ErrInvalidFmt
+		}
+		h.Format = binary.LittleEndian.Uint16(chunk[24:26])
+	}
+
+	if h.Channels == 0 || h.SampleRate == 0 {
+		return h, ErrInvalidFmt
+	}
+	switch {
+	case h.Format == FormatPCM && (h.BitsPerSample == 8 || h.BitsPerSample == 16 || h.BitsPerSample == 24 || h.BitsPerSample == 32):
+	case h.Format == FormatIEEEFloat && h.BitsPerSample == 32:
+	default:
+		return h, fmt.Errorf("%w: formato %#x com %d bits", ErrUnsupportedFormat, h.Format, h.BitsPerSample)
+	}
+	if h.BlockAlign != h.Channels*h.BitsPerSample/8 {
+		return h, ErrInvalidFmt
+	}
+	return h, nil
+}
+
+// PCM é um áudio PCM 16 bits com amostras intercaladas por canal
+type PCM struct {
+	SampleRate int
+	Channels   int
+	Samples    []int16
+}
+
+// Frames retorna a quantidade de amostras por canal
+func (p *PCM) Frames() int {
+	if p.Channels == 0 {
+		return 0
+	}
+	return len(p.Samples) / p.Channels
+}
+
+// Duration retorna a duração do áudio em segundos
+func (p *PCM) Duration() float64 {
+	if p.SampleRate == 0 {
+		return 0
+	}
+	return float64(p.Frames()) / float64(p.SampleRate)
+}
+
+// Mono mistura os canais em um único canal
+func (p *PCM) Mono() *PCM {
+	if p.Channels <= 1 {
+		return p
+	}
+
+	frames := p.Frames()
+	out := make([]int16, frames)
+	for i := 0; i < frames; i++ {
+		var sum int
+		for c := 0; c < p.Channels; c++ {
+			sum += int(p.Samples[i*p.Channels+c])
+		}
+		out[i] = int16(sum / p.Channels)
+	}
+	return &PCM{SampleRate: p.SampleRate, Channels: 1, Samples: out}
+}
+
+// Decode lê um WAV e converte as amostras para PCM 16 bits
+func Decode(data []byte) (*PCM, error) {
+	h, body, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	bytesPerSample := h.BitsPerSample / 8
+	samples := make([]int16, len(body)/bytesPerSample)
+	for i := range samples {
+		s := body[i*bytesPerSample:]
+		switch {
+		case h.Format == FormatIEEEFloat:
+			f := float64(math.Float32frombits(binary.LittleEndian.Uint32(s)))
+			samples[i] = int16(math.Max(-1, math.Min(1, f)) * math.MaxInt16)
+		case bytesPerSample == 1:
+			samples[i] = (int16(s[0]) - 128) << 8
+		default:
+			// Mantém os 16 bits mais significativos
+			samples[i] = int16(binary.LittleEndian.Uint16(s[bytesPerSample-2:]))
+		}
+	}
+
+	return &PCM{SampleRate: h.SampleRate, Channels: h.Channels, Samples: samples}, nil
+}
+
+// Encode gera um WAV PCM 16 bits com cabeçalho canônico
+func Encode(p *PCM) []byte {
+	var buf bytes.Buffer
+	buf.Grow(44 + len(p.Samples)*2)
+	buf.Write(header(p.SampleRate, p.Channels, uint32(len(p.Samples)*2)))
+	binary.Write(&buf, binary.LittleEndian, p.Samples)
+	return buf.Bytes()
+}
+
+// header monta o cabeçalho canônico de 44 bytes para PCM 16 bits
+func header(sampleRate, channels int, dataSize uint32) []byte {
+	blockAlign := uint16(channels * 2)
+
+	h := make([]byte, 0, 44)
+	h = append(h, "RIFF"...)
+	h = binary.LittleEndian.AppendUint32(h, 36+dataSize)
+	h = append(h, "WAVEfmt "...)
+	h = binary.LittleEndian.AppendUint32(h, 16)
+	h = binary.LittleEndian.AppendUint16(h, FormatPCM)
+	h = binary.LittleEndian.AppendUint16(h, uint16(channels))
+	h = binary.LittleEndian.AppendUint32(h, uint32(sampleRate))
+	h = binary.LittleEndian.AppendUint32(h, uint32(sampleRate)*uint32(blockAlign))
+	h = binary.LittleEndian.AppendUint16(h, blockAlign)
+	h = binary.LittleEndian.AppendUint16(h, 16)
+	h = append(h, "data"...)
+	h = binary.LittleEndian.AppendUint32(h, dataSize)
+	return h
+}
+
+// StreamHeader monta um cabeçalho PCM 16 bits para transmissão contínua,
+// quando o tamanho final ainda é desconhecido. Os campos de tamanho recebem
+// o valor máximo, convenção aceita pela maioria dos players.
+func StreamHeader(sampleRate, channels int) []byte {
+	h := header(sampleRate, channels, 0)
+	binary.LittleEndian.PutUint32(h[4:8], math.MaxUint32)
+	binary.LittleEndian.PutUint32(h[40:44], math.MaxUint32)
+	return h
+}
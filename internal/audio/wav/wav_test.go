@@ -0,0 +1,118 @@
+package wav
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// chunk monta um chunk RIFF com o tamanho declarado informado, que pode
+// diferir do corpo para simular arquivos malformados
+func chunk(id string, declared uint32, body []byte) []byte {
+	c := append([]byte(id), binary.LittleEndian.AppendUint32(nil, declared)...)
+	return append(c, body...)
+}
+
+// riff envolve os chunks no cabeçalho RIFF/WAVE
+func riff(chunks ...[]byte) []byte {
+	var body []byte
+	for _, c := range chunks {
+		body = append(body, c...)
+	}
+	data := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(4+len(body)))...)
+	data = append(data, "WAVE"...)
+	return append(data, body...)
+}
+
+func fmtBody(format uint16, channels, sampleRate, blockAlign, bits int) []byte {
+	b := binary.LittleEndian.AppendUint16(nil, format)
+	b = binary.LittleEndian.AppendUint16(b, uint16(channels))
+	b = binary.LittleEndian.AppendUint32(b, uint32(sampleRate))
+	b = binary.LittleEndian.AppendUint32(b, uint32(sampleRate*blockAlign))
+	b = binary.LittleEndian.AppendUint16(b, uint16(blockAlign))
+	return binary.LittleEndian.AppendUint16(b, uint16(bits))
+}
+
+func extensibleBody(subformat uint16, channels, sampleRate, bits int) []byte {
+	b := fmtBody(FormatExtensible, channels, sampleRate, channels*bits/8, bits)
+	b = binary.LittleEndian.AppendUint16(b, 22)           // cbSize
+	b = binary.LittleEndian.AppendUint16(b, uint16(bits)) // bits válidos
+	b = binary.LittleEndian.AppendUint32(b, 0)            // máscara de canais
+	b = binary.LittleEndian.AppendUint16(b, subformat)
+	return append(b, make([]byte, 14)...) // restante do GUID
+}
+
+func seeds() [][]byte {
+	pcm := fmtBody(FormatPCM, 1, 22050, 2, 16)
+	samples := make([]byte, 64)
+
+	return [][]byte{
+		Encode(&PCM{SampleRate: 22050, Channels: 1, Samples: []int16{1, -1, 300, -300}}),
+		StreamHeader(16000, 2),
+		// LIST e fact antes do áudio
+		riff(chunk("LIST", 4, []byte("INFO")), chunk("fact", 4, []byte{4, 0, 0, 0}), chunk("fmt ", 16, pcm), chunk("data", 64, samples)),
+		// WAVE_FORMAT_EXTENSIBLE com PCM 24 bits e float 32 bits
+		riff(chunk("fmt ", 40, extensibleBody(FormatPCM, 2, 48000, 24)), chunk("data", 60, samples[:60])),
+		riff(chunk("fmt ", 40, extensibleBody(FormatIEEEFloat, 1, 44100, 32)), chunk("data", 64, samples)),
+		// Chunk de tamanho ímpar seguido do byte de preenchimento
+		riff(chunk("junk", 3, []byte{1, 2, 3, 0}), chunk("fmt ", 16, pcm), chunk("data", 63, samples[:63])),
+		// data maior que o arquivo e data antes de fmt
+		riff(chunk("fmt ", 16, pcm), chunk("data", 1<<31, samples[:10])),
+		riff(chunk("data", 64, samples), chunk("fmt ", 16, pcm)),
+		// Cabeçalhos truncados
+		[]byte("RIFF"),
+		riff(chunk("fmt ", 16, pcm[:10])),
+		riff(chunk("fmt ", 16, pcm)[:20]),
+		// Zero canais e BlockAlign 0
+		riff(chunk("fmt ", 16, fmtBody(FormatPCM, 0, 22050, 0, 16)), chunk("data", 64, samples)),
+		riff(chunk("fmt ", 16, fmtBody(FormatPCM, 1, 22050, 0, 16)), chunk("data", 64, samples)),
+		riff(chunk("fmt ", 16, fmtBody(FormatPCM, 1, 22050, 2, 0)), chunk("data", 64, samples)),
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range seeds() {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		h, body, err := Parse(data)
+		if err != nil {
+			return
+		}
+		if h.BlockAlign <= 0 || h.Channels <= 0 || h.SampleRate <= 0 {
+			t.Fatalf("cabeçalho aceito com campos inválidos: %+v", h)
+		}
+		if h.DataSize != len(body) || h.DataSize > len(data) {
+			t.Fatalf("DataSize %d difere do corpo (%d) ou excede a entrada (%d)", h.DataSize, len(body), len(data))
+		}
+		if h.DataSize%h.BlockAlign != 0 || h.Frames()*h.BlockAlign != h.DataSize {
+			t.Fatalf("DataSize %d não é múltiplo de BlockAlign %d", h.DataSize, h.BlockAlign)
+		}
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	for _, seed := range seeds() {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p, err := Decode(data)
+		if err != nil {
+			return
+		}
+		h, _, _ := Parse(data)
+		if want := h.DataSize / (h.BitsPerSample / 8); len(p.Samples) != want {
+			t.Fatalf("%d amostras, esperado %d", len(p.Samples), want)
+		}
+		if p.Frames() != h.Frames() || len(p.Samples)%p.Channels != 0 {
+			t.Fatalf("%d quadros, esperado %d", p.Frames(), h.Frames())
+		}
+
+		// O áudio decodificado sobrevive a uma nova codificação
+		again, err := Decode(Encode(p))
+		if err != nil || len(again.Samples) != len(p.Samples) {
+			t.Fatalf("recodificação falhou: %v", err)
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"tts-api/internal/config"
+	"tts-api/internal/timing"
+)
+
+// Entry é um áudio já codificado, com os metadados da resposta
+type Entry struct {
+	Key        string
+	Voice      string
+	Data       []byte
+	MimeType   string
+	Codec      string
+	Duration   float64
+	SampleRate int
+	Sentences  []timing.Sentence
+	CreatedAt  time.Time
+}
+
+// size aproxima a memória ocupada pela entrada
+func (e *Entry) size() int64 {
+	return int64(len(e.Data) + len(e.Key) + len(e.Voice) + len(e.MimeType) + len(e.Codec))
+}
+
+// Stats resume o uso do cache
+type Stats struct {
+	Entries     int    `json:"entries"`      // Entradas em memória
+	MemoryBytes int64  `json:"memory_bytes"` // Bytes em memória
+	DiskEntries int    `json:"disk_entries"`
+	DiskBytes   int64  `json:"disk_bytes"`
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+}
+
+// Cache guarda áudios sintetizados em um LRU em memória limitado por bytes
+// e, opcionalmente, em disco com validade e limite de tamanho
+type Cache struct {
+	maxBytes int64
+	ttl      time.Duration
+	disk     *diskStore // nil quando desativado
+
+	mu    sync.Mutex
+	size  int64
+	order *list.List // mais recente na frente
+	items map[string]*list.Element
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// New cria o cache conforme CACHE_MEMORY_BYTES, CACHE_DIR,
+// CACHE_TTL_MINUTES e CACHE_DISK_MAX_BYTES
+func New(cfg *config.Config) (*Cache, error) {
+	c := &Cache{
+		maxBytes: cfg.CacheMemoryBytes,
+		ttl:      time.Duration(cfg.CacheTTLMinutes) * time.Minute,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+	if cfg.CacheDir != "" {
+		disk, err := newDiskStore(cfg.CacheDir, cfg.CacheDiskMaxBytes, c.ttl)
+		if err != nil {
+			return nil, err
+		}
+		c.disk = disk
+	}
+	return c, nil
+}
+
+// Enabled indica se algum dos níveis está ativo
+func (c *Cache) Enabled() bool {
+	return c != nil && (c.maxBytes > 0 || c.disk != nil)
+}
+
+// Key gera a chave a partir dos parâmetros que determinam o áudio
+func Key(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get procura a entrada na memória e depois no disco; entradas do disco
+// passam a ocupar a memória
+func (c *Cache) Get(key string) (*Entry, bool) {
+	if !c.Enabled() {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*Entry)
+		if !c.expired(entry) {
+			c.order.MoveToFront(el)
+			c.mu.Unlock()
+			c.hits.Add(1)
+			return entry, true
+		}
+		c.removeLocked(el)
+	}
+	c.mu.Unlock()
+
+	if c.disk != nil {
+		if entry, ok := c.disk.get(key); ok {
+			c.putMemory(entry)
+			c.hits.Add(1)
+			return entry, true
+		}
+	}
+	c.misses.Add(1)
+	return nil, false
+}
+
+// Put guarda a entrada nos níveis ativos
+func (c *Cache) Put(entry *Entry) error {
+	if !c.Enabled() {
+		return nil
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	c.putMemory(entry)
+	if c.disk != nil {
+		return c.disk.put(entry)
+	}
+	return nil
+}
+
+// PurgeKey remove a entrada de todos os níveis
+func (c *Cache) PurgeKey(key string) bool {
+	c.mu.Lock()
+	el, found := c.items[key]
+	if found {
+		c.removeLocked(el)
+	}
+	c.mu.Unlock()
+
+	if c.disk != nil && c.disk.remove(key) {
+		found = true
+	}
+	return found
+}
+
+// PurgeVoice remove as entradas da voz e retorna quantas foram removidas
+func (c *Cache) PurgeVoice(voice string) int {
+	removed := make(map[string]bool)
+	c.mu.Lock()
+	for key, el := range c.items {
+		if el.Value.(*Entry).Voice == voice {
+			c.removeLocked(el)
+			removed[key] = true
+		}
+	}
+	c.mu.Unlock()
+
+	if c.disk != nil {
+		for _, key := range c.disk.removeVoice(voice) {
+			removed[key] = true
+		}
+	}
+	return len(removed)
+}
+
+// Purge esvazia o cache e retorna quantas entradas foram removidas
+func (c *Cache) Purge() int {
+	removed := make(map[string]bool)
+	c.mu.Lock()
+	for key := range c.items {
+		removed[key] = true
+	}
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+	c.size = 0
+	c.mu.Unlock()
+
+	if c.disk != nil {
+		for _, key := range c.disk.removeAll() {
+			removed[key] = true
+		}
+	}
+	return len(removed)
+}
+
+// Stats retorna o uso atual do cache
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	stats := Stats{Entries: len(c.items), MemoryBytes: c.size}
+	c.mu.Unlock()
+
+	if c.disk != nil {
+		stats.DiskEntries, stats.DiskBytes = c.disk.usage()
+	}
+	stats.Hits = c.hits.Load()
+	stats.Misses = c.misses.Load()
+	return stats
+}
+
+// putMemory insere a entrada no LRU, descartando as menos usadas até
+// respeitar o limite de bytes. Entradas maiores que o limite não são
+// guardadas em memória.
+func (c *Cache) putMemory(entry *Entry) {
+	if entry.size() > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[entry.Key]; ok {
+		c.removeLocked(el)
+	}
+	c.items[entry.Key] = c.order.PushFront(entry)
+	c.size += entry.size()
+
+	for c.size > c.maxBytes {
+		c.removeLocked(c.order.Back())
+	}
+}
+
+// removeLocked remove o elemento do LRU; requer c.mu
+func (c *Cache) removeLocked(el *list.Element) {
+	entry := el.Value.(*Entry)
+	c.order.Remove(el)
+	delete(c.items, entry.Key)
+	c.size -= entry.size()
+}
+
+func (c *Cache) expired(entry *Entry) bool {
+	return c.ttl > 0 && time.Since(entry.CreatedAt) > c.ttl
+}
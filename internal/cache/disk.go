@@ -0,0 +1,216 @@
+package cache
+
+import (
+	"encoding/gob"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileExt é a extensão das entradas gravadas em disco
+const fileExt = ".gob"
+
+// diskItem é o índice em memória de uma entrada gravada
+type diskItem struct {
+	voice     string
+	size      int64
+	createdAt time.Time
+}
+
+// diskStore grava as entradas em <dir>/<voz>/<chave>.gob. O índice é
+// reconstruído a partir dos arquivos na inicialização.
+type diskStore struct {
+	dir      string
+	maxBytes int64 // 0 = ilimitado
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	size  int64
+	items map[string]diskItem
+}
+
+func newDiskStore(dir string, maxBytes int64, ttl time.Duration) (*diskStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("erro ao criar o diretório do cache: %v", err)
+	}
+
+	s := &diskStore{dir: dir, maxBytes: maxBytes, ttl: ttl, items: make(map[string]diskItem)}
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*"+fileExt))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		key := strings.TrimSuffix(filepath.Base(file), fileExt)
+		s.items[key] = diskItem{
+			voice:     filepath.Base(filepath.Dir(file)),
+			size:      info.Size(),
+			createdAt: info.ModTime(),
+		}
+		s.size += info.Size()
+	}
+
+	s.mu.Lock()
+	s.evictLocked()
+	s.mu.Unlock()
+	return s, nil
+}
+
+func (s *diskStore) get(key string) (*Entry, bool) {
+	s.mu.Lock()
+	item, ok := s.items[key]
+	if ok && s.expired(item) {
+		s.removeLocked(key)
+		ok = false
+	}
+	s.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	f, err := os.Open(s.path(item.voice, key))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	var entry Entry
+	if err := gob.NewDecoder(f).Decode(&entry); err != nil {
+		log.Printf("Aviso: entrada %s do cache ignorada: %v", key, err)
+		return nil, false
+	}
+	return &entry, true
+}
+
+// put grava a entrada via arquivo temporário e descarta as entradas
+// vencidas e, se preciso, as mais antigas até respeitar o limite
+func (s *diskStore) put(entry *Entry) error {
+	dir := filepath.Join(s.dir, entry.Voice)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("erro ao gravar no cache: %v", err)
+	}
+	tmp, err := os.CreateTemp(dir, entry.Key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("erro ao gravar no cache: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(entry); err != nil {
+		tmp.Close()
+		return fmt.Errorf("erro ao gravar no cache: %v", err)
+	}
+	info, err := tmp.Stat()
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("erro ao gravar no cache: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("erro ao gravar no cache: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Rename(tmp.Name(), s.path(entry.Voice, entry.Key)); err != nil {
+		return fmt.Errorf("erro ao gravar no cache: %v", err)
+	}
+	if old, ok := s.items[entry.Key]; ok {
+		s.size -= old.size
+	}
+	s.items[entry.Key] = diskItem{voice: entry.Voice, size: info.Size(), createdAt: entry.CreatedAt}
+	s.size += info.Size()
+	s.evictLocked()
+	return nil
+}
+
+func (s *diskStore) remove(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.items[key]; !ok {
+		return false
+	}
+	s.removeLocked(key)
+	return true
+}
+
+func (s *diskStore) removeVoice(voice string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for key, item := range s.items {
+		if item.voice == voice {
+			s.removeLocked(key)
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (s *diskStore) removeAll() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.items))
+	for key := range s.items {
+		s.removeLocked(key)
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (s *diskStore) usage() (int, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items), s.size
+}
+
+// evictLocked remove as entradas vencidas e as mais antigas enquanto o
+// total exceder maxBytes; requer s.mu
+func (s *diskStore) evictLocked() {
+	for key, item := range s.items {
+		if s.expired(item) {
+			s.removeLocked(key)
+		}
+	}
+	if s.maxBytes <= 0 || s.size <= s.maxBytes {
+		return
+	}
+
+	keys := make([]string, 0, len(s.items))
+	for key := range s.items {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return s.items[keys[i]].createdAt.Before(s.items[keys[j]].createdAt)
+	})
+	for _, key := range keys {
+		if s.size <= s.maxBytes {
+			break
+		}
+		s.removeLocked(key)
+	}
+}
+
+// removeLocked apaga o arquivo e a entrada do índice; requer s.mu
+func (s *diskStore) removeLocked(key string) {
+	item := s.items[key]
+	if err := os.Remove(s.path(item.voice, key)); err != nil && !os.IsNotExist(err) {
+		log.Printf("Aviso: erro ao remover %s do cache: %v", key, err)
+	}
+	s.size -= item.size
+	delete(s.items, key)
+}
+
+func (s *diskStore) path(voice, key string) string {
+	return filepath.Join(s.dir, voice, key+fileExt)
+}
+
+func (s *diskStore) expired(item diskItem) bool {
+	return s.ttl > 0 && time.Since(item.createdAt) > s.ttl
+}
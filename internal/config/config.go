@@ -1,6 +1,9 @@
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
 	"strconv"
 	"strings"
@@ -11,23 +14,166 @@ type Config struct {
 	AuthToken string
 	Voices    []string
 	VoicesDir string
-	MaxTexto  int // Novo campo adicionado
+	MaxTexto  int    // Novo campo adicionado
+	Engine    string // Nome do engine de síntese registrado em voice.RegisterEngine
+
+	// Monitoramento de VoicesDir: "auto" (inotify, com consulta periódica
+	// se indisponível), "poll" ou "off"
+	VoicesWatch       string
+	VoicesPollSeconds int // Intervalo da consulta periódica
+
+	// Catálogo de vozes para instalação (VOICE_FILES e /admin/voices)
+	VoicesManifestURL       string
+	VoicesBaseURL           string
+	VoicesCatalogTTLMinutes int // Tempo que o manifesto fica em memória
+
+	// Pool de processos do piper
+	PiperBinary       string
+	PoolSize          int            // Quantidade padrão de workers por voz (0 desativa o pool)
+	PoolSizes         map[string]int // Quantidade de workers específica por voz
+	WorkerMaxRequests int            // Requisições atendidas antes de reciclar o worker (0 = ilimitado)
+	ProsodyPools      int            // Conjuntos de prosódia personalizada com worker próprio por voz (0 = um processo por requisição)
+
+	// Saída em OGG/Opus
+	OpusBitrate    int // bits por segundo
+	OpusSampleRate int
+
+	// Saída em MP3
+	MP3Bitrate int // bits por segundo, CBR
+
+	// Jobs assíncronos
+	JobWorkers          int    // Jobs processados simultaneamente
+	JobQueueSize        int    // Jobs aguardando na fila antes de recusar novos
+	JobRetentionMinutes int    // Tempo que o resultado fica disponível após o término
+	WebhookSecret       string // Chave HMAC dos webhooks (padrão: AUTH_TOKEN)
+
+	// Hosts de callback_url liberados mesmo em endereços internos (loopback,
+	// redes privadas e link-local), recusados por padrão
+	WebhookAllowedHosts []string
+
+	// Vozes da API compatível com OpenAI mapeadas para vozes instaladas
+	OpenAIVoices map[string]string
+
+	// Servidor Wyoming (Home Assistant); vazio desativa
+	WyomingPort string
+
+	// Servidor gRPC; vazio desativa
+	GRPCPort string
+
+	// Origens aceitas em /ws/synthesize além da própria ("*" aceita todas)
+	WSAllowedOrigins []string
+
+	// Expande números, datas, moedas e abreviações antes da síntese
+	TextNormalization bool
+
+	// Diretório dos léxicos de pronúncia, com um subdiretório por voz;
+	// vazio usa o diretório da própria voz
+	LexiconDir string
+
+	// Textos longos são divididos em trechos de até SynthChunkSize
+	// caracteres (0 desativa), sintetizados em paralelo
+	SynthChunkSize   int
+	SynthParallelism int // Trechos simultâneos por requisição (0 = tamanho do pool da voz)
+
+	// Cache de áudio: LRU em memória limitado por bytes (0 desativa) e, com
+	// CacheDir, um nível em disco com validade e limite de tamanho
+	CacheMemoryBytes  int64
+	CacheDir          string
+	CacheTTLMinutes   int   // Validade das entradas (0 = sem expiração)
+	CacheDiskMaxBytes int64 // 0 = ilimitado
+
+	// GET /synthesize: Cache-Control das respostas e URLs assinadas, que
+	// dispensam o token até expirar
+	HTTPCacheControl       string
+	SignedURLSecret        string // Chave HMAC das URLs (padrão: derivada do AUTH_TOKEN)
+	SignedURLSecretDerived bool   // SIGNED_URL_SECRET não foi definido
+	SignedURLTTLMinutes    int    // Validade padrão das URLs
+	SignedURLMaxTTLMinutes int    // Maior validade aceita em expires_in
+
+	// Fonemização com o espeak-ng (endpoint /phonemize)
+	EspeakBinary string
+	EspeakData   string // Diretório que contém espeak-ng-data da mesma versão do binário; vazio usa o padrão do binário
+
+	// Pacote de prompts pré-renderizados (GET /prompts); vazio desativa
+	PromptsFile         string
+	PromptsDir          string // Onde os áudios renderizados são gravados
+	PromptsCheckSeconds int    // Intervalo de verificação de mudanças (0 desativa)
 }
 
 func Load() *Config {
-	maxTextoStr := getEnvOrDefault("MAX_TEXTO", "100000")
-	maxTexto, err := strconv.Atoi(maxTextoStr)
-	if err != nil {
-		maxTexto = 100000
+	return &Config{
+		Port:              getEnvOrDefault("PORT", "8080"),
+		AuthToken:         getEnvOrDefault("AUTH_TOKEN", "default-token"),
+		Voices:            strings.Split(getEnvOrDefault("VOICE_FILES", ""), ","),
+		VoicesDir:         getEnvOrDefault("VOICES_DIR", "./voices"),
+		MaxTexto:          getEnvIntOrDefault("MAX_TEXTO", 100000),
+		Engine:            getEnvOrDefault("TTS_ENGINE", "piper"),
+		VoicesWatch:       getEnvOrDefault("VOICES_WATCH", "auto"),
+		VoicesPollSeconds: getEnvIntOrDefault("VOICES_POLL_SECONDS", 10),
+
+		VoicesManifestURL:       getEnvOrDefault("VOICES_MANIFEST_URL", "https://huggingface.co/rhasspy/piper-voices/raw/main/voices.json"),
+		VoicesBaseURL:           getEnvOrDefault("VOICES_BASE_URL", "https://huggingface.co/rhasspy/piper-voices/resolve/main/"),
+		VoicesCatalogTTLMinutes: getEnvIntOrDefault("VOICES_CATALOG_TTL_MINUTES", 60),
+
+		PiperBinary:       getEnvOrDefault("PIPER_BIN", "piper"),
+		PoolSize:          getEnvIntOrDefault("PIPER_POOL_SIZE", 2),
+		PoolSizes:         parseIntMap(getEnvOrDefault("PIPER_POOL_SIZES", "")),
+		WorkerMaxRequests: getEnvIntOrDefault("PIPER_WORKER_MAX_REQUESTS", 500),
+		ProsodyPools:      getEnvIntOrDefault("PIPER_PROSODY_POOLS", 4),
+		OpusBitrate:       getEnvIntOrDefault("OPUS_BITRATE", 32000),
+		OpusSampleRate:    getEnvIntOrDefault("OPUS_SAMPLE_RATE", 48000),
+		MP3Bitrate:        getEnvIntOrDefault("MP3_BITRATE", 64000),
+
+		JobWorkers:          getEnvIntOrDefault("JOB_WORKERS", 2),
+		JobQueueSize:        getEnvIntOrDefault("JOB_QUEUE_SIZE", 100),
+		JobRetentionMinutes: getEnvIntOrDefault("JOB_RETENTION_MINUTES", 60),
+		WebhookSecret:       getEnvOrDefault("WEBHOOK_SECRET", getEnvOrDefault("AUTH_TOKEN", "default-token")),
+		WebhookAllowedHosts: parseList(getEnvOrDefault("WEBHOOK_ALLOWED_HOSTS", "")),
+
+		OpenAIVoices: parseStringMap(getEnvOrDefault("OPENAI_VOICES", "")),
+		WyomingPort:  getEnvOrDefault("WYOMING_PORT", ""),
+		GRPCPort:     getEnvOrDefault("GRPC_PORT", ""),
+
+		WSAllowedOrigins: parseList(getEnvOrDefault("WS_ALLOWED_ORIGINS", "")),
+
+		TextNormalization: getEnvBoolOrDefault("TEXT_NORMALIZATION", true),
+		LexiconDir:        getEnvOrDefault("LEXICON_DIR", ""),
+		SynthChunkSize:    getEnvIntOrDefault("SYNTH_CHUNK_SIZE", 1000),
+		SynthParallelism:  getEnvIntOrDefault("SYNTH_PARALLELISM", 0),
+		CacheMemoryBytes:  int64(getEnvIntOrDefault("CACHE_MEMORY_BYTES", 64<<20)),
+		CacheDir:          getEnvOrDefault("CACHE_DIR", ""),
+		CacheTTLMinutes:   getEnvIntOrDefault("CACHE_TTL_MINUTES", 1440),
+		CacheDiskMaxBytes: int64(getEnvIntOrDefault("CACHE_DISK_MAX_BYTES", 1<<30)),
+		HTTPCacheControl:  getEnvOrDefault("HTTP_CACHE_CONTROL", "private, max-age=86400"),
+
+		SignedURLSecret:        getEnvOrDefault("SIGNED_URL_SECRET", deriveSecret(getEnvOrDefault("AUTH_TOKEN", "default-token"), "signed-url")),
+		SignedURLSecretDerived: os.Getenv("SIGNED_URL_SECRET") == "",
+		SignedURLTTLMinutes:    getEnvIntOrDefault("SIGNED_URL_TTL_MINUTES", 60),
+		SignedURLMaxTTLMinutes: getEnvIntOrDefault("SIGNED_URL_MAX_TTL_MINUTES", 1440),
+
+		EspeakBinary:        getEnvOrDefault("ESPEAK_BIN", "espeak-ng"),
+		EspeakData:          getEnvOrDefault("ESPEAK_DATA", ""),
+		PromptsFile:         getEnvOrDefault("PROMPTS_FILE", ""),
+		PromptsDir:          getEnvOrDefault("PROMPTS_DIR", "./prompts"),
+		PromptsCheckSeconds: getEnvIntOrDefault("PROMPTS_CHECK_SECONDS", 60),
 	}
+}
 
-	return &Config{
-		Port:      getEnvOrDefault("PORT", "8080"),
-		AuthToken: getEnvOrDefault("AUTH_TOKEN", "default-token"),
-		Voices:    strings.Split(getEnvOrDefault("VOICE_FILES", ""), ","),
-		VoicesDir: getEnvOrDefault("VOICES_DIR", "./voices"),
-		MaxTexto:  maxTexto, // Atribui o valor lido
+// VoicePoolSize retorna a quantidade de workers configurada para a voz
+func (c *Config) VoicePoolSize(voice string) int {
+	if size, ok := c.PoolSizes[voice]; ok {
+		return size
 	}
+	return c.PoolSize
+}
+
+// deriveSecret gera uma chave própria para o uso informado a partir de
+// outra, para que o token de autenticação não seja usado diretamente como
+// chave de assinatura
+func deriveSecret(secret, purpose string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return hex.EncodeToString(mac.Sum(nil))
 }
 
 func getEnvOrDefault(key, defaultValue string) string {
@@ -36,3 +182,62 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnvOrDefault(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvBoolOrDefault(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(getEnvOrDefault(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// parseList interpreta listas no formato "a,b,c", ignorando itens vazios
+func parseList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// parseIntMap interpreta listas no formato "chave=valor,chave=valor"
+func parseIntMap(value string) map[string]int {
+	result := make(map[string]int)
+	for _, item := range strings.Split(value, ",") {
+		key, raw, found := strings.Cut(item, "=")
+		if !found {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			continue
+		}
+		result[strings.TrimSpace(key)] = n
+	}
+	return result
+}
+
+// parseStringMap interpreta listas no formato "chave=valor,chave=valor"
+func parseStringMap(value string) map[string]string {
+	result := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		key, raw, found := strings.Cut(item, "=")
+		if !found {
+			continue
+		}
+		if key, raw = strings.TrimSpace(key), strings.TrimSpace(raw); key != "" && raw != "" {
+			result[key] = raw
+		}
+	}
+	return result
+}
@@ -0,0 +1,215 @@
+// Package grpcapi implementa a API gRPC definida em proto/gotts/v1/tts.proto.
+// O código em pb é gerado com protoc-gen-go e protoc-gen-go-grpc:
+//
+//	protoc -I proto --go_out=. --go_opt=module=tts-api \
+//	    --go-grpc_out=. --go-grpc_opt=module=tts-api gotts/v1/tts.proto
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"tts-api/internal/audio"
+	"tts-api/internal/grpcapi/pb"
+	"tts-api/internal/ssml"
+	"tts-api/internal/voice"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implementa o serviço TTS usando o mesmo voice.Manager da API HTTP
+type Server struct {
+	pb.UnimplementedTTSServer
+	voiceManager *voice.Manager
+}
+
+// NewServer cria o servidor gRPC com autenticação pelo token informado
+func NewServer(vm *voice.Manager, token string) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryAuth(token)),
+		grpc.StreamInterceptor(streamAuth(token)),
+	)
+	pb.RegisterTTSServer(server, &Server{voiceManager: vm})
+	return server
+}
+
+func (s *Server) ListVoices(ctx context.Context, req *pb.ListVoicesRequest) (*pb.ListVoicesResponse, error) {
+	resp := &pb.ListVoicesResponse{}
+	for _, name := range s.voiceManager.ListVoices() {
+		info, exists := s.voiceManager.Voice(name)
+		if !exists {
+			continue
+		}
+		v := &pb.Voice{Name: name, Language: info.Language, SampleRate: int32(info.SampleRate)}
+		for _, speaker := range info.Speakers {
+			v.Speakers = append(v.Speakers, &pb.Speaker{Id: int32(speaker.ID), Name: speaker.Name})
+		}
+		resp.Voices = append(resp.Voices, v)
+	}
+	return resp, nil
+}
+
+func (s *Server) Synthesize(ctx context.Context, req *pb.SynthesizeRequest) (*pb.SynthesizeResponse, error) {
+	synthReq, encoding, encodeOpts, err := s.parseRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	wavData, err := s.voiceManager.Synthesize(ctx, synthReq)
+	if err != nil {
+		return nil, synthesisError(err)
+	}
+
+	encoded, err := audio.Encode(wavData, encoding, encodeOpts)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return &pb.SynthesizeResponse{
+		Audio:      encoded.Data,
+		Voice:      req.Voice,
+		MimeType:   encoded.MimeType,
+		Codec:      encoded.Codec,
+		SampleRate: int32(encoded.SampleRate),
+		Duration:   encoded.Duration,
+	}, nil
+}
+
+func (s *Server) StreamSynthesize(req *pb.SynthesizeRequest, stream grpc.ServerStreamingServer[pb.AudioChunk]) error {
+	synthReq, encoding, encodeOpts, err := s.parseRequest(req)
+	if err != nil {
+		return err
+	}
+
+	encoder, err := audio.NewStreamEncoder(encoding, encodeOpts)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// As frases são sintetizadas em paralelo e enviadas em ordem assim que
+	// ficam prontas; o contexto do stream é cancelado quando o cliente desiste
+	segments := ssml.FromText(synthReq.Text)
+	var sendErr error
+	err = ssml.RenderEach(stream.Context(), s.voiceManager, synthReq, segments, func(i int, wavData []byte) error {
+		data, duration, err := encoder.Encode(wavData)
+		if err != nil {
+			sendErr = encodeError(err)
+			return sendErr
+		}
+
+		sendErr = stream.Send(&pb.AudioChunk{
+			Audio:         data,
+			SentenceIndex: int32(i),
+			Text:          segments[i].Text,
+			Duration:      duration,
+			MimeType:      encoder.MimeType(),
+			SampleRate:    int32(encoder.SampleRate()),
+		})
+		return sendErr
+	})
+	switch {
+	case sendErr != nil:
+		return sendErr
+	case err != nil:
+		return synthesisError(err)
+	}
+	return nil
+}
+
+// parseRequest valida a requisição e converte para os tipos internos, com
+// as mesmas regras da API HTTP
+func (s *Server) parseRequest(req *pb.SynthesizeRequest) (voice.Request, audio.Encoding, audio.Options, error) {
+	cfg := s.voiceManager.Config
+	if req.Text == "" {
+		return voice.Request{}, "", audio.Options{}, status.Error(codes.InvalidArgument, "texto não pode estar vazio")
+	}
+	if len(req.Text) > cfg.MaxTexto {
+		return voice.Request{}, "", audio.Options{}, status.Errorf(codes.InvalidArgument, "o texto enviado excede o limite de %d caracteres", cfg.MaxTexto)
+	}
+	if !s.voiceManager.HasVoice(req.Voice) {
+		return voice.Request{}, "", audio.Options{}, status.Errorf(codes.NotFound, "voz %q não encontrada", req.Voice)
+	}
+
+	opts := voice.Options{
+		LengthScale:     req.LengthScale,
+		NoiseScale:      req.NoiseScale,
+		NoiseW:          req.NoiseW,
+		SentenceSilence: req.SentenceSilence,
+	}
+	if req.Speed != nil {
+		if req.LengthScale != nil {
+			return voice.Request{}, "", audio.Options{}, status.Error(codes.InvalidArgument, "informe apenas speed ou length_scale")
+		}
+		if err := voice.SpeedRange.Check("speed", *req.Speed); err != nil {
+			return voice.Request{}, "", audio.Options{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		lengthScale := 1 / *req.Speed
+		opts.LengthScale = &lengthScale
+	}
+	if err := opts.Validate(); err != nil {
+		return voice.Request{}, "", audio.Options{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	encoding := audio.EncodingWAV
+	if req.Encoding != "" {
+		var err error
+		if encoding, err = audio.ParseEncoding(req.Encoding); err != nil {
+			return voice.Request{}, "", audio.Options{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	encodeOpts := audio.Options{
+		Opus: audio.OpusOptions{Bitrate: cfg.OpusBitrate, SampleRate: cfg.OpusSampleRate},
+		MP3:  audio.MP3Options{Bitrate: cfg.MP3Bitrate},
+	}
+	if req.SampleRate != nil {
+		encodeOpts.SampleRate = int(*req.SampleRate)
+		encodeOpts.Opus.SampleRate = int(*req.SampleRate)
+	}
+	var source int
+	if info, exists := s.voiceManager.Voice(req.Voice); exists {
+		source = info.SampleRate
+	}
+	if req.Bitrate != nil {
+		encodeOpts.Opus.Bitrate = int(*req.Bitrate)
+		encodeOpts.MP3.Bitrate = int(*req.Bitrate)
+	} else {
+		encodeOpts.FitMP3Bitrate(encoding, source)
+	}
+	if err := encodeOpts.ValidateFor(encoding, source); err != nil {
+		return voice.Request{}, "", audio.Options{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	synthReq := voice.Request{
+		Voice:   req.Voice,
+		Text:    req.Text,
+		Speaker: req.Speaker,
+		Options: opts,
+	}
+	return synthReq, encoding, encodeOpts, nil
+}
+
+// synthesisError converte erros da síntese em status gRPC
+func synthesisError(err error) error {
+	var speakerErr *voice.SpeakerError
+	switch {
+	case errors.As(err, &speakerErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, voice.ErrPhonemesUnsupported):
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func encodeError(err error) error {
+	if errors.Is(err, audio.ErrCodecUnavailable) {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	return status.Errorf(codes.Internal, "erro ao codificar o áudio: %v", err)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"tts-api/internal/audio"
+	"tts-api/internal/cache"
+	"tts-api/internal/ssml"
+	"tts-api/internal/voice"
+)
+
+// cacheKeyPattern valida as chaves informadas na remoção
+var cacheKeyPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+type CacheHandler struct {
+	voiceManager *voice.Manager
+	cache        *cache.Cache
+}
+
+func NewCacheHandler(vm *voice.Manager, c *cache.Cache) *CacheHandler {
+	return &CacheHandler{voiceManager: vm, cache: c}
+}
+
+// CachePurgeResponse informa quantas entradas foram removidas
+type CachePurgeResponse struct {
+	Removed int `json:"removed"`
+}
+
+// Stats retorna o uso do cache
+// @Summary      Consulta o cache de áudio
+// @Tags         Administração
+// @Produce      json
+// @Success      200  {object}  cache.Stats
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Router       /admin/cache [get]
+// @Security     ApiKeyAuth
+func (h *CacheHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	writeJSONResponse(w, http.StatusOK, h.cache.Stats())
+}
+
+// Purge remove entradas do cache
+// @Summary      Remove entradas do cache de áudio
+// @Description  Remove a entrada da chave informada (cabeçalho X-Cache-Key da síntese), todas as entradas de uma voz (inclusive de vozes já removidas) ou, sem parâmetros, todo o cache
+// @Tags         Administração
+// @Produce      json
+// @Param        key query string false "Chave da entrada"
+// @Param        voice query string false "Nome da voz"
+// @Success      200  {object}  handlers.CachePurgeResponse
+// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse "Chave não encontrada"
+// @Router       /admin/cache [delete]
+// @Security     ApiKeyAuth
+func (h *CacheHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	voiceName := r.URL.Query().Get("voice")
+
+	switch {
+	case key != "" && voiceName != "":
+		writeJSONError(w, http.StatusBadRequest, "Informe apenas key ou voice")
+	case key != "":
+		if !cacheKeyPattern.MatchString(key) {
+			writeJSONError(w, http.StatusBadRequest, "Chave inválida")
+			return
+		}
+		if !h.cache.PurgeKey(key) {
+			writeJSONError(w, http.StatusNotFound, "Chave não encontrada no cache")
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, CachePurgeResponse{Removed: 1})
+	case voiceName != "":
+		// A voz pode já ter sido removida e ainda ter entradas no disco
+		writeJSONResponse(w, http.StatusOK, CachePurgeResponse{Removed: h.cache.PurgeVoice(voiceName)})
+	default:
+		writeJSONResponse(w, http.StatusOK, CachePurgeResponse{Removed: h.cache.Purge()})
+	}
+}
+
+// synthesisKey identifica o áudio pelo texto como é enviado ao engine
+// (após o léxico e a normalização), pela voz, locutor e prosódia efetivos,
+// pela versão dos modelos e léxicos usados e pelo formato de saída. É a
+// chave do cache e a base do ETag, que assim mudam quando o modelo ou o
+// léxico da voz é alterado, inclusive em SSML, em que o léxico só é
+// aplicado na síntese de cada segmento.
+func (h *TTSHandler) synthesisKey(req *SynthesizeRequest, synthReq voice.Request, segments []ssml.Segment, encoding audio.Encoding, encodeOpts audio.Options, timed bool) string {
+	info, _ := h.voiceManager.Voice(synthReq.Voice)
+
+	textType := req.TextType
+	text := synthReq.Text
+	if textType == "" || textType == textTypeText {
+		textType = textTypeText
+		text = h.voiceManager.PrepareText(info, text, synthReq.SkipNormalization)
+	}
+
+	speaker := synthReq.Speaker
+	if speaker != "" {
+		if id, err := info.ResolveSpeaker(speaker); err == nil {
+			speaker = strconv.Itoa(id)
+		}
+	}
+
+	// Vozes trocadas em <voice> também determinam o áudio
+	var versions []string
+	for _, name := range append([]string{synthReq.Voice}, ssml.Voices(segments)...) {
+		version, _ := h.voiceManager.ModelVersion(name)
+		versions = append(versions, name+"="+version+"+"+h.voiceManager.Lexicons().Version(name))
+	}
+
+	return cache.Key(
+		textType,
+		strings.TrimSpace(text),
+		synthReq.Voice,
+		speaker,
+		strings.Join(versions, ","),
+		synthReq.Options.WithDefaults(info.Defaults).String(),
+		string(encoding),
+		fmt.Sprintf("%+v", encodeOpts),
+		strconv.FormatBool(timed),
+	)
+}
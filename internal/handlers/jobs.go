@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"tts-api/internal/jobs"
+	"tts-api/internal/voice"
+)
+
+type JobsHandler struct {
+	tts  *TTSHandler
+	jobs *jobs.Manager
+}
+
+func NewJobsHandler(tts *TTSHandler, jm *jobs.Manager) *JobsHandler {
+	return &JobsHandler{tts: tts, jobs: jm}
+}
+
+// JobRequest é uma requisição de síntese assíncrona
+type JobRequest struct {
+	SynthesizeRequest
+	// URL que recebe um POST com o estado final do job (concluído ou com
+	// falha), assinado com HMAC-SHA256 no cabeçalho X-GoTTS-Signature
+	CallbackURL string `json:"callback_url,omitempty"`
+}
+
+// Create enfileira uma síntese assíncrona
+// @Summary      Cria um job de síntese
+// @Description  Enfileira a síntese do texto e retorna o id do job para acompanhamento. Ao terminar, o estado final é enviado para callback_url (se informado) com a assinatura HMAC-SHA256 de "<X-GoTTS-Timestamp>.<corpo>" em X-GoTTS-Signature. Endereços internos (loopback, redes privadas e link-local) são recusados, exceto os hosts de WEBHOOK_ALLOWED_HOSTS.
+// @Tags         Jobs
+// @Accept       json
+// @Produce      json
+// @Param        JobRequest body handlers.JobRequest true "Requisição de síntese"
+// @Success      202  {object}  jobs.Job
+// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      503  {object}  handlers.ErrorResponse
+// @Router       /jobs [post]
+// @Security     ApiKeyAuth
+func (h *JobsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req JobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Erro ao ler requisição")
+		return
+	}
+
+	opts, ok := h.tts.validateRequest(w, &req.SynthesizeRequest)
+	if !ok {
+		return
+	}
+
+	// A voz e o locutor são verificados agora para que o erro não apareça
+	// apenas depois, no job
+	info, exists := h.tts.voiceManager.Voice(req.Voice)
+	if !exists {
+		writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"erro":             fmt.Sprintf("voz %s não encontrada", req.Voice),
+			"vozesDisponiveis": h.tts.voiceManager.ListVoices(),
+		})
+		return
+	}
+	if req.Speaker != "" {
+		var speakerErr *voice.SpeakerError
+		if _, err := info.ResolveSpeaker(string(req.Speaker)); errors.As(err, &speakerErr) {
+			writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+				"erro":                 err.Error(),
+				"locutoresDisponiveis": append([]voice.Speaker{}, speakerErr.Available...),
+			})
+			return
+		}
+	}
+
+	encoding, encodeOpts, err := h.tts.outputOptions(r, &req.SynthesizeRequest, "binary")
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.CallbackURL != "" {
+		if err := h.jobs.CheckCallbackURL(req.CallbackURL); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	segments, ok := h.tts.segments(w, &req.SynthesizeRequest)
+	if !ok {
+		return
+	}
+
+	job, err := h.jobs.Submit(jobs.Spec{
+		Request: voice.Request{
+			Voice:             req.Voice,
+			Text:              req.Text,
+			Speaker:           string(req.Speaker),
+			Options:           opts,
+			SkipNormalization: req.skipNormalization(),
+			Phonemes:          req.TextType == textTypePhonemes,
+		},
+		Segments:      segments,
+		Encoding:      encoding,
+		EncodeOptions: encodeOpts,
+		CallbackURL:   req.CallbackURL,
+	})
+	if errors.Is(err, jobs.ErrQueueFull) {
+		w.Header().Set("Retry-After", "30")
+		writeJSONError(w, http.StatusServiceUnavailable, "Fila de jobs cheia, tente novamente mais tarde")
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	writeJSONResponse(w, http.StatusAccepted, job)
+}
+
+// Get retorna o estado de um job
+// @Summary      Consulta um job
+// @Description  Retorna o estado e o progresso (frases ou segmentos SSML sintetizados) do job
+// @Tags         Jobs
+// @Produce      json
+// @Param        id path string true "Id do job"
+// @Success      200  {object}  jobs.Job
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Router       /jobs/{id} [get]
+// @Security     ApiKeyAuth
+func (h *JobsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	job, exists := h.jobs.Get(r.PathValue("id"))
+	if !exists {
+		writeJSONError(w, http.StatusNotFound, "Job não encontrado ou expirado")
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, job)
+}
+
+// Audio retorna o áudio de um job concluído
+// @Summary      Baixa o áudio de um job
+// @Description  Retorna o áudio gerado no formato solicitado na criação do job
+// @Tags         Jobs
+// @Produce      audio/wav, audio/ogg, audio/mpeg, audio/pcm, audio/PCMU, audio/PCMA
+// @Param        id path string true "Id do job"
+// @Success      200  {file}    binary
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Failure      409  {object}  handlers.ErrorResponse
+// @Router       /jobs/{id}/audio [get]
+// @Security     ApiKeyAuth
+func (h *JobsHandler) Audio(w http.ResponseWriter, r *http.Request) {
+	job, encoded, exists := h.jobs.Result(r.PathValue("id"))
+	if !exists {
+		writeJSONError(w, http.StatusNotFound, "Job não encontrado ou expirado")
+		return
+	}
+	if encoded == nil {
+		writeJSONResponse(w, http.StatusConflict, map[string]interface{}{
+			"erro":   "Job não concluído",
+			"status": job.Status,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", encoded.MimeType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(encoded.Data)))
+	w.Header().Set("X-Duration-Seconds", fmt.Sprintf("%.2f", encoded.Duration))
+	w.Header().Set("X-Sample-Rate", strconv.Itoa(encoded.SampleRate))
+	w.WriteHeader(http.StatusOK)
+	w.Write(encoded.Data)
+}
+
+// Delete cancela um job em andamento ou remove um job finalizado
+// @Summary      Cancela ou remove um job
+// @Description  Jobs na fila ou em execução são cancelados e continuam consultáveis até expirar; jobs finalizados são removidos junto com o áudio
+// @Tags         Jobs
+// @Produce      json
+// @Param        id path string true "Id do job"
+// @Success      200  {object}  jobs.Job
+// @Success      204
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Router       /jobs/{id} [delete]
+// @Security     ApiKeyAuth
+func (h *JobsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	job, removed, err := h.jobs.Cancel(r.PathValue("id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Job não encontrado ou expirado")
+		return
+	}
+	if removed {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, job)
+}
@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"tts-api/internal/lexicon"
+	"tts-api/internal/voice"
+)
+
+type LexiconHandler struct {
+	voiceManager *voice.Manager
+}
+
+func NewLexiconHandler(vm *voice.Manager) *LexiconHandler {
+	return &LexiconHandler{voiceManager: vm}
+}
+
+// LexiconResponse lista as entradas do léxico de uma voz
+type LexiconResponse struct {
+	Voice   string          `json:"voice"`
+	Entries []lexicon.Entry `json:"entries"`
+}
+
+// LexiconEntryRequest é a pronúncia de uma palavra: informe alias ou phonemes
+type LexiconEntryRequest struct {
+	Alias    string `json:"alias,omitempty"`    // Grafia lida no lugar da palavra
+	Phonemes string `json:"phonemes,omitempty"` // Fonemas IPA
+}
+
+// LexiconImportResponse resume uma importação
+type LexiconImportResponse struct {
+	Imported int `json:"imported"` // Entradas lidas do arquivo
+	Created  int `json:"created"`  // Palavras que não existiam no léxico
+	Total    int `json:"total"`    // Entradas no léxico após a importação
+}
+
+// List retorna o léxico da voz
+// @Summary      Lista ou exporta o léxico de uma voz
+// @Description  Retorna as pronúncias personalizadas da voz em JSON, CSV (word,alias,phonemes) ou W3C PLS
+// @Tags         Léxico
+// @Produce      json, text/csv, application/pls+xml
+// @Param        voice path string true "Nome da voz"
+// @Param        format query string false "Formato (json, csv ou pls)" default(json)
+// @Success      200  {object}  handlers.LexiconResponse
+// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Router       /voices/{voice}/lexicon [get]
+// @Security     ApiKeyAuth
+func (h *LexiconHandler) List(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.voice(w, r)
+	if !ok {
+		return
+	}
+	format, err := lexicon.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	l, ok := h.lexicon(w, info.Name)
+	if !ok {
+		return
+	}
+
+	entries := l.Entries()
+	switch format {
+	case lexicon.FormatCSV:
+		w.Header().Set("Content-Type", format.MimeType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, info.Name))
+		lexicon.WriteCSV(w, entries)
+	case lexicon.FormatPLS:
+		w.Header().Set("Content-Type", format.MimeType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pls"`, info.Name))
+		lexicon.WritePLS(w, entries, info.Language)
+	default:
+		writeJSONResponse(w, http.StatusOK, LexiconResponse{Voice: info.Name, Entries: entries})
+	}
+}
+
+// Get retorna a pronúncia de uma palavra
+// @Summary      Consulta uma palavra do léxico
+// @Tags         Léxico
+// @Produce      json
+// @Param        voice path string true "Nome da voz"
+// @Param        word path string true "Palavra"
+// @Success      200  {object}  lexicon.Entry
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Router       /voices/{voice}/lexicon/{word} [get]
+// @Security     ApiKeyAuth
+func (h *LexiconHandler) Get(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.voice(w, r)
+	if !ok {
+		return
+	}
+	l, ok := h.lexicon(w, info.Name)
+	if !ok {
+		return
+	}
+
+	entry, exists := l.Get(r.PathValue("word"))
+	if !exists {
+		writeJSONError(w, http.StatusNotFound, "Palavra não encontrada no léxico")
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, entry)
+}
+
+// Put inclui ou altera a pronúncia de uma palavra
+// @Summary      Inclui ou altera uma palavra do léxico
+// @Description  A palavra é substituída no texto sem diferenciar maiúsculas e apenas quando isolada (não faz parte de outra palavra). Fonemas são enviados ao engine entre [[ ]].
+// @Tags         Léxico
+// @Accept       json
+// @Produce      json
+// @Param        voice path string true "Nome da voz"
+// @Param        word path string true "Palavra"
+// @Param        LexiconEntryRequest body handlers.LexiconEntryRequest true "Pronúncia"
+// @Success      200  {object}  lexicon.Entry
+// @Success      201  {object}  lexicon.Entry
+// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Failure      500  {object}  handlers.ErrorResponse
+// @Router       /voices/{voice}/lexicon/{word} [put]
+// @Security     ApiKeyAuth
+func (h *LexiconHandler) Put(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.voice(w, r)
+	if !ok {
+		return
+	}
+
+	var req LexiconEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Erro ao ler requisição")
+		return
+	}
+	entry := lexicon.Entry{
+		Word:     strings.TrimSpace(r.PathValue("word")),
+		Alias:    strings.TrimSpace(req.Alias),
+		Phonemes: strings.TrimSpace(req.Phonemes),
+	}
+	if err := entry.Validate(); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	created, err := h.voiceManager.Lexicons().Set(info.Name, entry)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	status := http.StatusOK
+	if created > 0 {
+		status = http.StatusCreated
+	}
+	writeJSONResponse(w, status, entry)
+}
+
+// Delete remove uma palavra do léxico
+// @Summary      Remove uma palavra do léxico
+// @Tags         Léxico
+// @Param        voice path string true "Nome da voz"
+// @Param        word path string true "Palavra"
+// @Success      204
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Failure      500  {object}  handlers.ErrorResponse
+// @Router       /voices/{voice}/lexicon/{word} [delete]
+// @Security     ApiKeyAuth
+func (h *LexiconHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.voice(w, r)
+	if !ok {
+		return
+	}
+
+	removed, err := h.voiceManager.Lexicons().Delete(info.Name, r.PathValue("word"))
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !removed {
+		writeJSONError(w, http.StatusNotFound, "Palavra não encontrada no léxico")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Import importa entradas de um arquivo CSV, PLS ou JSON
+// @Summary      Importa um léxico
+// @Description  O corpo é o arquivo no formato indicado em format ou, na falta dele, pelo Content-Type (text/csv, application/pls+xml ou application/json). Com mode=replace o léxico atual é descartado; com merge (padrão) as palavras do arquivo são incluídas ou atualizadas.
+// @Tags         Léxico
+// @Accept       json, text/csv, application/pls+xml
+// @Produce      json
+// @Param        voice path string true "Nome da voz"
+// @Param        format query string false "Formato do arquivo (json, csv ou pls)"
+// @Param        mode query string false "merge ou replace" default(merge)
+// @Success      200  {object}  handlers.LexiconImportResponse
+// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Failure      500  {object}  handlers.ErrorResponse
+// @Router       /voices/{voice}/lexicon/import [post]
+// @Security     ApiKeyAuth
+func (h *LexiconHandler) Import(w http.ResponseWriter, r *http.Request) {
+	info, ok := h.voice(w, r)
+	if !ok {
+		return
+	}
+
+	name := r.URL.Query().Get("format")
+	if name == "" {
+		name = formatFromContentType(r.Header.Get("Content-Type"))
+	}
+	format, err := lexicon.ParseFormat(name)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	mode := r.URL.Query().Get("mode")
+	if mode != "" && mode != "merge" && mode != "replace" {
+		writeJSONError(w, http.StatusBadRequest, "mode deve ser merge ou replace")
+		return
+	}
+
+	entries, err := lexicon.Read(r.Body, format)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	store := h.voiceManager.Lexicons()
+	response := LexiconImportResponse{Imported: len(entries)}
+	if mode == "replace" {
+		err = store.Replace(info.Name, entries)
+		response.Created = len(entries)
+	} else {
+		response.Created, err = store.Set(info.Name, entries...)
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if l, err := store.Lexicon(info.Name); err == nil {
+		response.Total = l.Len()
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// voice valida a voz do caminho
+func (h *LexiconHandler) voice(w http.ResponseWriter, r *http.Request) (voice.VoiceInfo, bool) {
+	name := r.PathValue("voice")
+	info, exists := h.voiceManager.Voice(name)
+	if !exists {
+		writeJSONResponse(w, http.StatusNotFound, map[string]interface{}{
+			"erro":             fmt.Sprintf("voz %s não encontrada", name),
+			"vozesDisponiveis": h.voiceManager.ListVoices(),
+		})
+		return voice.VoiceInfo{}, false
+	}
+	return info, true
+}
+
+func (h *LexiconHandler) lexicon(w http.ResponseWriter, name string) (*lexicon.Lexicon, bool) {
+	l, err := h.voiceManager.Lexicons().Lexicon(name)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Erro ao carregar o léxico: %v", err))
+		return nil, false
+	}
+	return l, true
+}
+
+// formatFromContentType deduz o formato do léxico pelo Content-Type
+func formatFromContentType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "text/csv":
+		return string(lexicon.FormatCSV)
+	case strings.HasSuffix(mediaType, "xml"):
+		return string(lexicon.FormatPLS)
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"tts-api/internal/normalize"
+	"tts-api/internal/voice"
+)
+
+// NormalizeRequest é o texto a normalizar e a voz ou o idioma das regras
+type NormalizeRequest struct {
+	Text     string `json:"text"`
+	Voice    string `json:"voice,omitempty"`    // Usa o idioma e o léxico da voz
+	Language string `json:"language,omitempty"` // Idioma quando a voz não é informada ou não declara idioma (pt_BR, en_US)
+}
+
+// NormalizeResponse traz o texto como ele é enviado ao engine
+type NormalizeResponse struct {
+	Text       string `json:"text"`
+	Normalized string `json:"normalized"`
+	Language   string `json:"language"`
+}
+
+// Normalize retorna o texto normalizado, para ajuste das regras
+// @Summary      Normaliza um texto
+// @Description  Retorna o texto com o léxico da voz aplicado e números, valores, datas, horários, ordinais, porcentagens, telefones, CPF/CNPJ e abreviações por extenso, como é enviado ao engine na síntese
+// @Tags         TTS
+// @Accept       json
+// @Produce      json
+// @Param        NormalizeRequest body handlers.NormalizeRequest true "Texto e voz ou idioma"
+// @Success      200  {object}  handlers.NormalizeResponse
+// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Router       /normalize [post]
+// @Security     ApiKeyAuth
+func (h *TTSHandler) Normalize(w http.ResponseWriter, r *http.Request) {
+	var req NormalizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Erro ao ler requisição")
+		return
+	}
+	if req.Text == "" {
+		writeJSONError(w, http.StatusBadRequest, "Texto não pode estar vazio")
+		return
+	}
+	if len(req.Text) > h.voiceManager.Config.MaxTexto {
+		writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"erro":         "O texto enviado excede o limite estabelecido",
+			"limite":       h.voiceManager.Config.MaxTexto,
+			"tamanhoTexto": len(req.Text),
+		})
+		return
+	}
+
+	// Sem voz, apenas as regras do idioma são aplicadas
+	info := voice.VoiceInfo{Language: req.Language}
+	if req.Voice != "" {
+		var exists bool
+		if info, exists = h.voiceManager.Voice(req.Voice); !exists {
+			writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+				"erro":             fmt.Sprintf("voz %s não encontrada", req.Voice),
+				"vozesDisponiveis": h.voiceManager.ListVoices(),
+			})
+			return
+		}
+		if info.Language == "" {
+			info.Language = req.Language
+		}
+	}
+
+	language := info.Language
+	if _, ok := normalize.For(language); !ok {
+		var mensagem string
+		switch {
+		case language != "":
+			mensagem = fmt.Sprintf("Não há normalização para o idioma %q", language)
+		case req.Voice != "":
+			mensagem = fmt.Sprintf("A voz %s não informa o idioma; use o campo language", req.Voice)
+		default:
+			mensagem = "Informe voice ou language"
+		}
+		writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"erro":               mensagem,
+			"idiomasDisponiveis": normalize.Languages(),
+		})
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, NormalizeResponse{
+		Text:       req.Text,
+		Normalized: h.voiceManager.PrepareText(info, req.Text, false),
+		Language:   language,
+	})
+}
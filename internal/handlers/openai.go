@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"tts-api/internal/audio"
+	"tts-api/internal/voice"
+)
+
+// openAIPCMSampleRate é a taxa do formato pcm da API da OpenAI
+const openAIPCMSampleRate = 24000
+
+// openAIFormats mapeia os valores de response_format suportados
+var openAIFormats = map[string]audio.Encoding{
+	"mp3":  audio.EncodingMP3,
+	"opus": audio.EncodingOggOpus,
+	"wav":  audio.EncodingWAV,
+	"pcm":  audio.EncodingPCM,
+}
+
+// OpenAIHandler implementa a API de fala compatível com a da OpenAI
+type OpenAIHandler struct {
+	voiceManager *voice.Manager
+}
+
+func NewOpenAIHandler(vm *voice.Manager) *OpenAIHandler {
+	return &OpenAIHandler{voiceManager: vm}
+}
+
+// OpenAISpeechRequest segue o corpo de POST /v1/audio/speech da OpenAI
+type OpenAISpeechRequest struct {
+	Model          string   `json:"model"` // Aceito por compatibilidade e ignorado
+	Input          string   `json:"input"`
+	Voice          string   `json:"voice"` // Nome OpenAI (OPENAI_VOICES) ou de uma voz instalada
+	ResponseFormat string   `json:"response_format,omitempty" enums:"mp3,opus,wav,pcm"`
+	Speed          *float64 `json:"speed,omitempty" minimum:"0.25" maximum:"4"`
+	StreamFormat   string   `json:"stream_format,omitempty" enums:"audio"`
+}
+
+// OpenAIError é o corpo de erro no formato da API da OpenAI
+type OpenAIError struct {
+	Error OpenAIErrorDetail `json:"error"`
+}
+
+type OpenAIErrorDetail struct {
+	Message string  `json:"message"`
+	Type    string  `json:"type"`
+	Param   *string `json:"param"`
+	Code    *string `json:"code"`
+}
+
+// Speech sintetiza o texto no formato da API da OpenAI
+// @Summary      Síntese compatível com a OpenAI
+// @Description  Implementa POST /v1/audio/speech da OpenAI. As vozes da OpenAI (alloy, nova, ...) são mapeadas para vozes instaladas via OPENAI_VOICES; nomes de vozes instaladas também são aceitos. Formatos: mp3, opus, wav e pcm (24 kHz, 16 bits, mono).
+// @Tags         OpenAI
+// @Accept       json
+// @Produce      audio/mpeg, audio/ogg, audio/wav, audio/pcm
+// @Param        OpenAISpeechRequest body handlers.OpenAISpeechRequest true "Requisição de síntese"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  handlers.OpenAIError
+// @Failure      401  {object}  handlers.OpenAIError
+// @Failure      501  {object}  handlers.OpenAIError
+// @Router       /v1/audio/speech [post]
+// @Security     ApiKeyAuth
+func (h *OpenAIHandler) Speech(w http.ResponseWriter, r *http.Request) {
+	var req OpenAISpeechRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "", fmt.Sprintf("Erro ao ler requisição: %v", err))
+		return
+	}
+
+	cfg := h.voiceManager.Config
+	if strings.TrimSpace(req.Input) == "" {
+		WriteOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "input", "O campo input não pode estar vazio")
+		return
+	}
+	if len(req.Input) > cfg.MaxTexto {
+		WriteOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "input",
+			fmt.Sprintf("O texto enviado excede o limite de %d caracteres", cfg.MaxTexto))
+		return
+	}
+	if req.StreamFormat != "" && req.StreamFormat != "audio" {
+		WriteOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "stream_format", "Apenas stream_format audio é suportado")
+		return
+	}
+
+	voiceName, ok := h.resolveVoice(req.Voice)
+	if !ok {
+		WriteOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "voice",
+			fmt.Sprintf("Voz %q não disponível; use uma de: %s", req.Voice, strings.Join(h.availableVoices(), ", ")))
+		return
+	}
+
+	format := req.ResponseFormat
+	if format == "" {
+		format = "mp3"
+	}
+	encoding, supported := openAIFormats[format]
+	if !supported {
+		WriteOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "response_format",
+			fmt.Sprintf("response_format %q não suportado; use mp3, opus, wav ou pcm", format))
+		return
+	}
+
+	var opts voice.Options
+	if req.Speed != nil {
+		if err := voice.SpeedRange.Check("speed", *req.Speed); err != nil {
+			WriteOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "speed", err.Error())
+			return
+		}
+		lengthScale := 1 / *req.Speed
+		opts.LengthScale = &lengthScale
+	}
+
+	encodeOpts := audio.Options{
+		Opus: audio.OpusOptions{Bitrate: cfg.OpusBitrate, SampleRate: cfg.OpusSampleRate},
+		MP3:  audio.MP3Options{Bitrate: cfg.MP3Bitrate},
+	}
+	if encoding == audio.EncodingPCM {
+		encodeOpts.SampleRate = openAIPCMSampleRate
+	}
+	if info, exists := h.voiceManager.Voice(voiceName); exists {
+		encodeOpts.FitMP3Bitrate(encoding, info.SampleRate)
+	}
+
+	wavData, err := h.voiceManager.Synthesize(r.Context(), voice.Request{
+		Voice:   voiceName,
+		Text:    req.Input,
+		Options: opts,
+	})
+	if err != nil {
+		WriteOpenAIError(w, http.StatusInternalServerError, "server_error", "", err.Error())
+		return
+	}
+
+	encoded, err := audio.Encode(wavData, encoding, encodeOpts)
+	if errors.Is(err, audio.ErrCodecUnavailable) {
+		WriteOpenAIError(w, http.StatusNotImplemented, "invalid_request_error", "response_format",
+			fmt.Sprintf("response_format %s: %v", format, err))
+		return
+	}
+	if err != nil {
+		WriteOpenAIError(w, http.StatusInternalServerError, "server_error", "", fmt.Sprintf("Erro ao codificar o áudio: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", encoded.MimeType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(encoded.Data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(encoded.Data)
+}
+
+// resolveVoice converte o nome de voz da OpenAI para uma voz instalada
+func (h *OpenAIHandler) resolveVoice(name string) (string, bool) {
+	if mapped, ok := h.voiceManager.Config.OpenAIVoices[name]; ok && h.voiceManager.HasVoice(mapped) {
+		return mapped, true
+	}
+	if name != "" && h.voiceManager.HasVoice(name) {
+		return name, true
+	}
+	return "", false
+}
+
+// availableVoices lista os nomes OpenAI mapeados seguidos das vozes instaladas
+func (h *OpenAIHandler) availableVoices() []string {
+	var names []string
+	for alias, target := range h.voiceManager.Config.OpenAIVoices {
+		if h.voiceManager.HasVoice(target) {
+			names = append(names, alias)
+		}
+	}
+	sort.Strings(names)
+	return append(names, h.voiceManager.ListVoices()...)
+}
+
+// WriteOpenAIError escreve um erro no formato da API da OpenAI
+func WriteOpenAIError(w http.ResponseWriter, statusCode int, errType, param, message string) {
+	detail := OpenAIErrorDetail{Message: message, Type: errType}
+	if param != "" {
+		detail.Param = &param
+	}
+	writeJSONResponse(w, statusCode, OpenAIError{Error: detail})
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"tts-api/internal/voice"
+)
+
+// PhonemizeRequest é o texto a converter em fonemas pela voz
+type PhonemizeRequest struct {
+	Text  string `json:"text"`
+	Voice string `json:"voice"`
+
+	// Aplica a normalização do texto antes da fonemização (padrão: true)
+	Normalize *bool `json:"normalize,omitempty"`
+}
+
+// PhonemizeResponse traz os fonemas IPA, que podem ser editados e enviados
+// a /synthesize com text_type "phonemes"
+type PhonemizeResponse struct {
+	Voice    string `json:"voice"`
+	Text     string `json:"text"`
+	Phonemes string `json:"phonemes"`
+}
+
+// Phonemize retorna os fonemas que a voz usaria para o texto
+// @Summary      Converte um texto em fonemas
+// @Description  Retorna os fonemas IPA (espeak-ng) que a voz usaria para o texto, após o léxico e a normalização. O resultado pode ser ajustado e enviado a /synthesize com text_type "phonemes". Com o espeak-ng da distribuição, alguns fonemas podem diferir dos obtidos pelo fork embutido no piper.
+// @Tags         TTS
+// @Accept       json
+// @Produce      json
+// @Param        PhonemizeRequest body handlers.PhonemizeRequest true "Texto e voz"
+// @Success      200  {object}  handlers.PhonemizeResponse
+// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      501  {object}  handlers.ErrorResponse
+// @Router       /phonemize [post]
+// @Security     ApiKeyAuth
+func (h *TTSHandler) Phonemize(w http.ResponseWriter, r *http.Request) {
+	var req PhonemizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Erro ao ler requisição")
+		return
+	}
+	if req.Text == "" {
+		writeJSONError(w, http.StatusBadRequest, "Texto não pode estar vazio")
+		return
+	}
+	if len(req.Text) > h.voiceManager.Config.MaxTexto {
+		writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"erro":         "O texto enviado excede o limite estabelecido",
+			"limite":       h.voiceManager.Config.MaxTexto,
+			"tamanhoTexto": len(req.Text),
+		})
+		return
+	}
+	if !h.voiceManager.HasVoice(req.Voice) {
+		writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"erro":             "Voz não encontrada",
+			"vozesDisponiveis": h.voiceManager.ListVoices(),
+		})
+		return
+	}
+
+	skipNormalization := req.Normalize != nil && !*req.Normalize
+	phonemes, err := h.voiceManager.Phonemize(r.Context(), req.Voice, req.Text, skipNormalization)
+	if errors.Is(err, voice.ErrPhonemesUnsupported) {
+		writeJSONError(w, http.StatusNotImplemented, err.Error())
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, PhonemizeResponse{
+		Voice:    req.Voice,
+		Text:     req.Text,
+		Phonemes: phonemes,
+	})
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+	"tts-api/internal/prompts"
+	"tts-api/internal/voice"
+)
+
+type PromptsHandler struct {
+	voiceManager *voice.Manager
+	library      *prompts.Library
+}
+
+// NewPromptsHandler cria o handler; library nil indica que nenhum pacote
+// foi configurado
+func NewPromptsHandler(vm *voice.Manager, library *prompts.Library) *PromptsHandler {
+	return &PromptsHandler{voiceManager: vm, library: library}
+}
+
+// ListPromptsResponse traz a situação de todos os prompts do pacote
+type ListPromptsResponse struct {
+	Prompts []prompts.Info `json:"prompts"`
+}
+
+// List retorna os prompts do pacote
+// @Summary      Lista os prompts pré-renderizados
+// @Description  Retorna os prompts do pacote (PROMPTS_FILE) com a situação da renderização: pending, rendering, ready ou failed
+// @Tags         Prompts
+// @Produce      json
+// @Success      200  {object}  handlers.ListPromptsResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Router       /prompts [get]
+// @Security     ApiKeyAuth
+func (h *PromptsHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled(w) {
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, ListPromptsResponse{Prompts: h.library.List()})
+}
+
+// Get serve o áudio de um prompt
+// @Summary      Obtém o áudio de um prompt
+// @Description  Retorna o áudio pré-renderizado do prompt. Se ainda não estiver pronto, o prompt é renderizado na hora. O ETag muda quando o texto, os parâmetros, o léxico ou o modelo da voz mudam.
+// @Tags         Prompts
+// @Produce      audio/wav, audio/ogg, audio/mpeg, audio/pcm, audio/PCMU, audio/PCMA
+// @Param        id path string true "Id do prompt"
+// @Param        Range header string false "Intervalo de bytes"
+// @Param        If-None-Match header string false "ETag já obtido"
+// @Success      200
+// @Success      206
+// @Success      304
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Failure      500  {object}  handlers.ErrorResponse
+// @Router       /prompts/{id} [get]
+// @Security     ApiKeyAuth
+func (h *PromptsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if !h.enabled(w) {
+		return
+	}
+
+	id := r.PathValue("id")
+	info, path, err := h.library.Get(r.Context(), id)
+	if errors.Is(err, prompts.ErrNotFound) {
+		writeJSONError(w, http.StatusNotFound, fmt.Sprintf("prompt %s não encontrado", id))
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Erro ao renderizar o prompt %s: %v", id, err))
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Erro ao ler o prompt %s", id))
+		return
+	}
+	defer file.Close()
+
+	var modTime time.Time
+	if info.RenderedAt != nil {
+		modTime = *info.RenderedAt
+	}
+	w.Header().Set("Content-Type", info.MimeType)
+	w.Header().Set("ETag", `"`+info.Hash+`"`)
+	w.Header().Set("Cache-Control", h.voiceManager.Config.HTTPCacheControl)
+	w.Header().Set("X-Duration-Seconds", fmt.Sprintf("%.2f", info.Duration))
+	w.Header().Set("X-Sample-Rate", strconv.Itoa(info.SampleRate))
+
+	// ServeContent trata If-None-Match (304), Range (206) e HEAD
+	http.ServeContent(w, r, "", modTime, file)
+}
+
+func (h *PromptsHandler) enabled(w http.ResponseWriter) bool {
+	if h.library == nil {
+		writeJSONError(w, http.StatusNotFound, "Nenhum pacote de prompts configurado (PROMPTS_FILE)")
+		return false
+	}
+	return true
+}
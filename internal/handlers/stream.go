@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"tts-api/internal/audio"
+	"tts-api/internal/ssml"
+	"tts-api/internal/voice"
+)
+
+// streamErrorTrailer informa, ao final do fluxo, um erro ocorrido depois que
+// o áudio já começou a ser enviado
+const streamErrorTrailer = "X-Stream-Error"
+
+// streamChunk é o áudio de uma frase (ou o erro que interrompeu a síntese)
+type streamChunk struct {
+	wav []byte
+	err error
+}
+
+// synthesizeStream envia o áudio de cada segmento (frase ou pausa) assim que
+// fica pronto. A síntese da frase seguinte acontece enquanto a anterior é
+// transmitida; se o cliente desconectar, o restante é cancelado.
+func (h *TTSHandler) synthesizeStream(w http.ResponseWriter, r *http.Request, req voice.Request, segments []ssml.Segment, encoding audio.Encoding, encodeOpts audio.Options) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeJSONError(w, http.StatusInternalServerError, "Streaming não suportado pelo servidor")
+		return
+	}
+
+	encoder, err := audio.NewStreamEncoder(encoding, encodeOpts)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(segments) == 0 {
+		writeJSONError(w, http.StatusBadRequest, "Texto não pode estar vazio")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// Os segmentos são sintetizados em paralelo e entregues em ordem
+	chunks := make(chan streamChunk, 1)
+	go func() {
+		defer close(chunks)
+		err := ssml.RenderEach(ctx, h.voiceManager, req, segments, func(_ int, data []byte) error {
+			select {
+			case chunks <- streamChunk{wav: data}:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			select {
+			case chunks <- streamChunk{err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	started := false
+	var duration float64
+	for chunk := range chunks {
+		err := chunk.err
+		var data []byte
+		if err == nil {
+			var seconds float64
+			data, seconds, err = encoder.Encode(chunk.wav)
+			duration += seconds
+		}
+
+		if err != nil {
+			if !started {
+				// Nada foi enviado ainda: responde com o erro em JSON
+				writeStreamStartError(w, h.voiceManager, err)
+				return
+			}
+			if ctx.Err() == nil {
+				log.Printf("Erro durante o streaming da síntese: %v", err)
+				w.Header().Set(streamErrorTrailer, err.Error())
+			}
+			return
+		}
+
+		if !started {
+			w.Header().Set("Content-Type", encoder.MimeType())
+			w.Header().Set("X-Sample-Rate", strconv.Itoa(encoder.SampleRate()))
+			w.Header().Set("X-Accel-Buffering", "no")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("Trailer", streamErrorTrailer+", X-Duration-Seconds")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+
+		if _, err := w.Write(data); err != nil {
+			// Cliente desconectou; o cancelamento interrompe a síntese restante
+			return
+		}
+		flusher.Flush()
+	}
+
+	if started {
+		w.Header().Set("X-Duration-Seconds", fmt.Sprintf("%.2f", duration))
+	}
+}
+
+// writeStreamStartError responde a um erro ocorrido antes do primeiro trecho
+// de áudio, com o mesmo formato usado pela síntese não contínua
+func writeStreamStartError(w http.ResponseWriter, vm *voice.Manager, err error) {
+	var speakerErr *voice.SpeakerError
+	switch {
+	case errors.As(err, &speakerErr):
+		writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"erro":                 err.Error(),
+			"locutoresDisponiveis": append([]voice.Speaker{}, speakerErr.Available...),
+		})
+	case errors.Is(err, voice.ErrPhonemesUnsupported):
+		writeJSONError(w, http.StatusNotImplemented, err.Error())
+	case errors.Is(err, context.Canceled):
+		// Cliente desconectou antes do primeiro trecho
+	default:
+		writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"erro":             err.Error(),
+			"vozesDisponiveis": vm.ListVoices(),
+		})
+	}
+}
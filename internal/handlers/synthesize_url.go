@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"tts-api/internal/signedurl"
+	"tts-api/internal/voice"
+)
+
+// SignURLRequest é a síntese a ser acessada por uma URL assinada
+type SignURLRequest struct {
+	SynthesizeRequest
+	Format    string `json:"format,omitempty" enums:"binary,srt,vtt"` // Áudio (padrão) ou legendas
+	ExpiresIn int    `json:"expires_in,omitempty"`                    // Validade em segundos (padrão: SIGNED_URL_TTL_MINUTES; máximo: SIGNED_URL_MAX_TTL_MINUTES)
+}
+
+// SignURLResponse traz o caminho assinado de GET /synthesize
+type SignURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SynthesizeGet sintetiza a partir da query string, com validadores HTTP
+// @Summary      Sintetiza texto em áudio via GET
+// @Description  Variante cacheável de POST /synthesize para uso direto em <audio> e CDNs. Retorna o áudio binário com ETag determinístico (derivado do texto normalizado, da voz e da versão do seu modelo, da prosódia e do formato; muda quando a voz é atualizada), responde 304 a If-None-Match e 206 a Range. Aceita o token de autenticação ou uma URL assinada gerada por POST /synthesize/url.
+// @Tags         TTS
+// @Produce      json, audio/wav, audio/ogg, audio/mpeg, audio/pcm, audio/PCMU, audio/PCMA, application/x-subrip, text/vtt
+// @Param        text query string true "Texto"
+// @Param        voice query string true "Nome da voz"
+// @Param        speaker query string false "Locutor por id ou nome"
+// @Param        text_type query string false "text, ssml ou phonemes" default(text)
+// @Param        normalize query bool false "Normaliza o texto" default(true)
+// @Param        format query string false "Áudio ou legendas" default(binary) Enums(binary, srt, vtt)
+// @Param        encoding query string false "Codec do áudio; também negociado via Accept" default(wav)
+// @Param        bitrate query int false "Bitrate em bps para formatos comprimidos"
+// @Param        sample_rate query int false "Taxa de amostragem de saída em Hz"
+// @Param        speed query number false "Velocidade da fala"
+// @Param        length_scale query number false "Duração dos fonemas"
+// @Param        noise_scale query number false "Variação de entonação"
+// @Param        noise_w query number false "Variação na duração dos fonemas"
+// @Param        sentence_silence query number false "Segundos de silêncio após cada frase"
+// @Param        expires query int false "Validade da URL assinada (segundos Unix)"
+// @Param        signature query string false "Assinatura da URL"
+// @Param        Range header string false "Intervalo de bytes"
+// @Param        If-None-Match header string false "ETag já obtido"
+// @Success      200
+// @Success      206
+// @Success      304
+// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      403  {object}  handlers.ErrorResponse
+// @Router       /synthesize [get]
+// @Security     ApiKeyAuth
+func (h *TTSHandler) SynthesizeGet(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req, err := synthesizeRequestFromQuery(query)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	opts, ok := h.validateRequest(w, &req)
+	if !ok {
+		return
+	}
+
+	format := query.Get("format")
+	switch format {
+	case "":
+		format = "binary"
+	case "binary", formatSRT, formatVTT:
+	default:
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("format inválido: %q (use binary, srt ou vtt)", format))
+		return
+	}
+
+	encoding, encodeOpts, err := h.outputOptions(r, &req, "binary")
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	synthReq := voice.Request{
+		Voice:             req.Voice,
+		Text:              req.Text,
+		Speaker:           string(req.Speaker),
+		Options:           opts,
+		SkipNormalization: req.skipNormalization(),
+		Phonemes:          req.TextType == textTypePhonemes,
+	}
+	segments, ok := h.segments(w, &req)
+	if !ok {
+		return
+	}
+
+	subtitles := format == formatSRT || format == formatVTT
+	key := h.synthesisKey(&req, synthReq, segments, encoding, encodeOpts, subtitles)
+	etag := `"` + key + `"`
+	if subtitles {
+		etag = `"` + key + "-" + format + `"`
+	}
+
+	// O ETag depende apenas da requisição, então o 304 dispensa a síntese
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", h.voiceManager.Config.HTTPCacheControl)
+	w.Header().Set("Vary", "Accept")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	entry, ok := h.audio(w, r, &req, synthReq, segments, subtitles, encoding, encodeOpts, key)
+	if !ok {
+		return
+	}
+
+	var body []byte
+	if subtitles {
+		var buf bytes.Buffer
+		writeSubtitles(&buf, format, entry.Sentences)
+		w.Header().Set("Content-Type", subtitleMimeType(format))
+		body = buf.Bytes()
+	} else {
+		w.Header().Set("Content-Type", entry.MimeType)
+		w.Header().Set("X-Duration-Seconds", fmt.Sprintf("%.2f", entry.Duration))
+		w.Header().Set("X-Sample-Rate", strconv.Itoa(entry.SampleRate))
+		body = entry.Data
+	}
+
+	// ServeContent trata Range (206), If-Range e HEAD
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(body))
+}
+
+// SignURL gera uma URL assinada para GET /synthesize
+// @Summary      Gera uma URL assinada de síntese
+// @Description  Retorna o caminho de GET /synthesize com os parâmetros da síntese, a validade e a assinatura HMAC. A URL dispensa o token de autenticação até expirar e pode ser usada diretamente em <audio> ou <track>.
+// @Tags         TTS
+// @Accept       json
+// @Produce      json
+// @Param        SignURLRequest body handlers.SignURLRequest true "Parâmetros da síntese"
+// @Success      200  {object}  handlers.SignURLResponse
+// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Router       /synthesize/url [post]
+// @Security     ApiKeyAuth
+func (h *TTSHandler) SignURL(w http.ResponseWriter, r *http.Request) {
+	var req SignURLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Erro ao ler requisição")
+		return
+	}
+	if _, ok := h.validateRequest(w, &req.SynthesizeRequest); !ok {
+		return
+	}
+	if !h.voiceManager.HasVoice(req.Voice) {
+		writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"erro":             fmt.Sprintf("voz %s não encontrada", req.Voice),
+			"vozesDisponiveis": h.voiceManager.ListVoices(),
+		})
+		return
+	}
+	if req.ExpiresIn < 0 {
+		writeJSONError(w, http.StatusBadRequest, "expires_in deve ser positivo")
+		return
+	}
+	maxTTL := time.Duration(h.voiceManager.Config.SignedURLMaxTTLMinutes) * time.Minute
+	if time.Duration(req.ExpiresIn)*time.Second > maxTTL {
+		writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"erro":   "expires_in excede a validade máxima das URLs assinadas",
+			"limite": int(maxTTL.Seconds()),
+		})
+		return
+	}
+
+	ttl := min(time.Duration(h.voiceManager.Config.SignedURLTTLMinutes)*time.Minute, maxTTL)
+	if req.ExpiresIn > 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+	}
+	expires := time.Now().Add(ttl).UTC().Truncate(time.Second)
+
+	params := req.queryValues()
+	if req.Format != "" {
+		params.Set("format", req.Format)
+	}
+	const path = "/synthesize"
+	query := signedurl.Sign(h.voiceManager.Config.SignedURLSecret, path, params, expires)
+	writeJSONResponse(w, http.StatusOK, SignURLResponse{URL: path + "?" + query, ExpiresAt: expires})
+}
+
+// synthesizeRequestFromQuery lê os parâmetros de GET /synthesize
+func synthesizeRequestFromQuery(q url.Values) (SynthesizeRequest, error) {
+	req := SynthesizeRequest{
+		Text:     q.Get("text"),
+		Voice:    q.Get("voice"),
+		Speaker:  SpeakerRef(q.Get("speaker")),
+		TextType: q.Get("text_type"),
+		Encoding: q.Get("encoding"),
+	}
+
+	var err error
+	if req.Normalize, err = boolParam(q, "normalize"); err != nil {
+		return req, err
+	}
+	if req.Bitrate, err = intParam(q, "bitrate"); err != nil {
+		return req, err
+	}
+	if req.SampleRate, err = intParam(q, "sample_rate"); err != nil {
+		return req, err
+	}
+	for name, field := range map[string]**float64{
+		"speed":            &req.Speed,
+		"length_scale":     &req.LengthScale,
+		"noise_scale":      &req.NoiseScale,
+		"noise_w":          &req.NoiseW,
+		"sentence_silence": &req.SentenceSilence,
+	} {
+		if *field, err = floatParam(q, name); err != nil {
+			return req, err
+		}
+	}
+	return req, nil
+}
+
+// queryValues converte a requisição nos parâmetros de GET /synthesize
+func (req *SynthesizeRequest) queryValues() url.Values {
+	q := url.Values{}
+	set := func(name, value string) {
+		if value != "" {
+			q.Set(name, value)
+		}
+	}
+	set("text", req.Text)
+	set("voice", req.Voice)
+	set("speaker", string(req.Speaker))
+	set("text_type", req.TextType)
+	set("encoding", req.Encoding)
+	if req.Normalize != nil {
+		set("normalize", strconv.FormatBool(*req.Normalize))
+	}
+	if req.Bitrate != nil {
+		set("bitrate", strconv.Itoa(*req.Bitrate))
+	}
+	if req.SampleRate != nil {
+		set("sample_rate", strconv.Itoa(*req.SampleRate))
+	}
+	for name, value := range map[string]*float64{
+		"speed":            req.Speed,
+		"length_scale":     req.LengthScale,
+		"noise_scale":      req.NoiseScale,
+		"noise_w":          req.NoiseW,
+		"sentence_silence": req.SentenceSilence,
+	} {
+		if value != nil {
+			set(name, strconv.FormatFloat(*value, 'f', -1, 64))
+		}
+	}
+	return q
+}
+
+func boolParam(q url.Values, name string) (*bool, error) {
+	if !q.Has(name) {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(q.Get(name))
+	if err != nil {
+		return nil, fmt.Errorf("parâmetro %s inválido: %q", name, q.Get(name))
+	}
+	return &value, nil
+}
+
+func intParam(q url.Values, name string) (*int, error) {
+	if !q.Has(name) {
+		return nil, nil
+	}
+	value, err := strconv.Atoi(q.Get(name))
+	if err != nil {
+		return nil, fmt.Errorf("parâmetro %s inválido: %q", name, q.Get(name))
+	}
+	return &value, nil
+}
+
+func floatParam(q url.Values, name string) (*float64, error) {
+	if !q.Has(name) {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(q.Get(name), 64)
+	if err != nil {
+		return nil, fmt.Errorf("parâmetro %s inválido: %q", name, q.Get(name))
+	}
+	return &value, nil
+}
+
+// etagMatches verifica se o ETag consta em If-None-Match
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"tts-api/internal/signedurl"
+)
+
+func TestEtagMatches(t *testing.T) {
+	const etag = `"abc"`
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{`"abc"`, true},
+		{`W/"abc"`, true},
+		{`"xyz", "abc"`, true},
+		{` "xyz" ,W/"abc" `, true},
+		{`*`, true},
+		{``, false},
+		{`abc`, false},
+		{`"abcd"`, false},
+		{`"xyz"`, false},
+	}
+	for _, tt := range tests {
+		if got := etagMatches(tt.header, etag); got != tt.want {
+			t.Errorf("etagMatches(%q) = %v, esperado %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func getSynthesize(h *TTSHandler, query string, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/synthesize?"+query, nil)
+	for key, values := range header {
+		r.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	h.SynthesizeGet(w, r)
+	return w
+}
+
+func TestSynthesizeGetConditional(t *testing.T) {
+	h := newTestHandler(t)
+	query := url.Values{"text": {"Olá mundo."}, "voice": {"fake"}}.Encode()
+
+	w := getSynthesize(h, query, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+	etag := w.Header().Get("ETag")
+	full := w.Body.Bytes()
+	if etag == "" || len(full) < 100 {
+		t.Fatalf("ETag %q e %d bytes", etag, len(full))
+	}
+
+	// O ETag é determinístico
+	if again := getSynthesize(h, query, nil); again.Header().Get("ETag") != etag {
+		t.Fatalf("ETag mudou entre requisições iguais: %q e %q", etag, again.Header().Get("ETag"))
+	}
+
+	w = getSynthesize(h, query, http.Header{"If-None-Match": {`"outro", ` + etag}})
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatalf("If-None-Match: status %d com %d bytes, esperado 304 vazio", w.Code, w.Body.Len())
+	}
+	if w.Header().Get("ETag") != etag {
+		t.Errorf("304 sem o ETag: %q", w.Header().Get("ETag"))
+	}
+
+	w = getSynthesize(h, query, http.Header{"Range": {"bytes=10-59"}})
+	if w.Code != http.StatusPartialContent {
+		t.Fatalf("Range: status %d, esperado 206", w.Code)
+	}
+	if got, want := w.Header().Get("Content-Range"), "bytes 10-59/"; !strings.HasPrefix(got, want) {
+		t.Errorf("Content-Range %q", got)
+	}
+	if !bytes.Equal(w.Body.Bytes(), full[10:60]) {
+		t.Errorf("Range retornou %d bytes diferentes do trecho pedido", w.Body.Len())
+	}
+
+	// If-Range com outro ETag devolve o áudio completo
+	w = getSynthesize(h, query, http.Header{"Range": {"bytes=10-59"}, "If-Range": {`"outro"`}})
+	if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), full) {
+		t.Errorf("If-Range divergente: status %d com %d bytes", w.Code, w.Body.Len())
+	}
+}
+
+func TestSignURLExpiresIn(t *testing.T) {
+	h := newTestHandler(t)
+	h.voiceManager.Config.SignedURLMaxTTLMinutes = 60
+
+	sign := func(expiresIn int) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(SignURLRequest{SynthesizeRequest: SynthesizeRequest{Text: "Olá", Voice: "fake"}, ExpiresIn: expiresIn})
+		w := httptest.NewRecorder()
+		h.SignURL(w, httptest.NewRequest(http.MethodPost, "/synthesize/url", bytes.NewReader(data)))
+		return w
+	}
+
+	w := sign(3601)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expires_in acima do máximo: status %d", w.Code)
+	}
+	if limit := decodeBody(t, w)["limite"]; limit != float64(3600) {
+		t.Errorf("limite = %v, esperado 3600", limit)
+	}
+
+	w = sign(3600)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+	var resp SignURLResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	u, err := url.Parse(resp.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signedurl.Verify(h.voiceManager.Config.SignedURLSecret, u.Path, u.Query(), time.Now()); err != nil {
+		t.Errorf("URL gerada não confere: %v", err)
+	}
+	if ttl := time.Until(resp.ExpiresAt); ttl > time.Hour || ttl < 59*time.Minute {
+		t.Errorf("validade de %v, esperado uma hora", ttl)
+	}
+}
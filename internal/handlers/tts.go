@@ -2,25 +2,124 @@ package handlers
 
 import (
 	"encoding/base64"
-	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"strconv"
+	"tts-api/internal/audio"
+	"tts-api/internal/cache"
+	"tts-api/internal/ssml"
+	"tts-api/internal/timing"
 	"tts-api/internal/voice"
 )
 
+// Formatos de legenda aceitos no parâmetro format
+const (
+	formatSRT = "srt"
+	formatVTT = "vtt"
+)
+
+// Valores aceitos em text_type
+const (
+	textTypeText     = "text"
+	textTypeSSML     = "ssml"
+	textTypePhonemes = "phonemes"
+)
+
 type TTSHandler struct {
 	voiceManager *voice.Manager
+	cache        *cache.Cache // nil desativa o cache
 }
 
 type SynthesizeRequest struct {
-	Text  string `json:"text"`
-	Voice string `json:"voice"`
+	Text    string     `json:"text"`
+	Voice   string     `json:"voice"`
+	Speaker SpeakerRef `json:"speaker,omitempty" swaggertype:"string"` // Locutor por id ou nome (modelos multi-locutor)
+
+	// Tipo do texto: "text" (padrão); "ssml", com <speak>, <break>,
+	// <say-as>, <prosody rate>, <voice name>, <sub>, <p> e <s>; ou
+	// "phonemes", fonemas IPA do espeak como os retornados por /phonemize
+	TextType string `json:"text_type,omitempty" enums:"text,ssml,phonemes"`
+
+	// Expande números, valores, datas, horários e abreviações conforme o
+	// idioma da voz antes da síntese (padrão: true)
+	Normalize *bool `json:"normalize,omitempty"`
+
+	// Inclui na resposta JSON o início e o fim de cada frase e palavra (os
+	// das palavras são estimados); implícito em format=srt e format=vtt
+	Timings bool `json:"timings,omitempty"`
+
+	// Formato de saída do áudio; quando omitido usa o parâmetro encoding da
+	// query string ou o cabeçalho Accept (format=binary)
+	Encoding   string `json:"encoding,omitempty" enums:"wav,ogg_opus,mp3,pcm_s16le,mulaw,alaw"`
+	Bitrate    *int   `json:"bitrate,omitempty"`     // Bitrate em bps para formatos comprimidos (mp3: 32000 a 192000, CBR; até 160000 abaixo de 32 kHz)
+	SampleRate *int   `json:"sample_rate,omitempty"` // Taxa de amostragem de saída em Hz (mulaw/alaw: 8000 por padrão)
+
+	// Parâmetros opcionais de prosódia; quando omitidos usam o padrão da voz.
+	// Valores diferentes do padrão usam um worker piper próprio, mantido para
+	// os PIPER_PROSODY_POOLS conjuntos usados mais recentemente em cada voz;
+	// fora deles cada requisição inicia um processo que recarrega o modelo.
+	Speed           *float64 `json:"speed,omitempty" minimum:"0.25" maximum:"4"`         // Velocidade da fala (inverso de length_scale)
+	LengthScale     *float64 `json:"length_scale,omitempty" minimum:"0.25" maximum:"4"`  // Duração dos fonemas (maior = mais lento)
+	NoiseScale      *float64 `json:"noise_scale,omitempty" minimum:"0" maximum:"2"`      // Variação de entonação
+	NoiseW          *float64 `json:"noise_w,omitempty" minimum:"0" maximum:"2"`          // Variação na duração dos fonemas
+	SentenceSilence *float64 `json:"sentence_silence,omitempty" minimum:"0" maximum:"5"` // Segundos de silêncio após cada frase
 }
 
-func NewTTSHandler(vm *voice.Manager) *TTSHandler {
-	return &TTSHandler{voiceManager: vm}
+// SpeakerRef aceita o locutor tanto como número (id) quanto como texto (nome)
+type SpeakerRef string
+
+func (s *SpeakerRef) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return err
+		}
+		*s = SpeakerRef(name)
+		return nil
+	}
+
+	var id json.Number
+	if err := json.Unmarshal(data, &id); err != nil {
+		return fmt.Errorf("locutor deve ser um id numérico ou um nome")
+	}
+	*s = SpeakerRef(id.String())
+	return nil
+}
+
+// prosodyOptions valida e converte os parâmetros de prosódia da requisição
+func (req *SynthesizeRequest) prosodyOptions() (voice.Options, error) {
+	opts := voice.Options{
+		LengthScale:     req.LengthScale,
+		NoiseScale:      req.NoiseScale,
+		NoiseW:          req.NoiseW,
+		SentenceSilence: req.SentenceSilence,
+	}
+
+	if req.Speed != nil {
+		if req.LengthScale != nil {
+			return opts, fmt.Errorf("informe apenas speed ou length_scale")
+		}
+		if err := voice.SpeedRange.Check("speed", *req.Speed); err != nil {
+			return opts, err
+		}
+		lengthScale := 1 / *req.Speed
+		opts.LengthScale = &lengthScale
+	}
+
+	return opts, opts.Validate()
+}
+
+// skipNormalization indica se o cliente desativou a normalização do texto
+func (req *SynthesizeRequest) skipNormalization() bool {
+	return req.Normalize != nil && !*req.Normalize
+}
+
+func NewTTSHandler(vm *voice.Manager, c *cache.Cache) *TTSHandler {
+	return &TTSHandler{voiceManager: vm, cache: c}
 }
 
 // Synthesize sintetiza o texto em áudio
@@ -28,11 +127,13 @@ func NewTTSHandler(vm *voice.Manager) *TTSHandler {
 // @Description  Converte texto em áudio utilizando a voz especificada
 // @Tags         TTS
 // @Accept       json
-// @Produce      json, audio/wav
-// @Param        format query string false "Formato de retorno do áudio (base64 ou binary)" default(base64)
+// @Produce      json, audio/wav, audio/ogg, audio/mpeg, audio/pcm, audio/PCMU, audio/PCMA, application/x-subrip, text/vtt
+// @Param        format query string false "Formato de retorno: base64, binary, ou legendas srt ou vtt sincronizadas com o áudio" default(base64) Enums(base64, binary, srt, vtt)
+// @Param        encoding query string false "Codec do áudio (wav, ogg_opus, mp3, pcm_s16le, mulaw ou alaw); também aceito no corpo ou via Accept" default(wav)
+// @Param        stream query bool false "Envia o áudio frase a frase via chunked transfer (wav, pcm_s16le, mulaw ou alaw); ignora format" default(false)
 // @Param        SynthesizeRequest body handlers.SynthesizeRequest true "Requisição de síntese"
 // @Success      200  {object}  handlers.SynthesizeResponse
-// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      400  {object}  handlers.RangeErrorResponse
 // @Failure      401  {object}  handlers.ErrorResponse
 // @Failure      500  {object}  handlers.ErrorResponse
 // @Router       /synthesize [post]
@@ -49,10 +150,195 @@ func (h *TTSHandler) Synthesize(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	opts, ok := h.validateRequest(w, &req)
+	if !ok {
+		return
+	}
+
+	// Obter o formato solicitado
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "base64" // Padrão é base64
+	}
+	// O streaming sempre envia o áudio binário
+	stream, _ := strconv.ParseBool(r.URL.Query().Get("stream"))
+	if stream {
+		format = "binary"
+	}
+
+	encoding, encodeOpts, err := h.outputOptions(r, &req, format)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	synthReq := voice.Request{
+		Voice:             req.Voice,
+		Text:              req.Text,
+		Speaker:           string(req.Speaker),
+		Options:           opts,
+		SkipNormalization: req.skipNormalization(),
+		Phonemes:          req.TextType == textTypePhonemes,
+	}
+
+	segments, ok := h.segments(w, &req)
+	if !ok {
+		return
+	}
+
+	if stream {
+		h.synthesizeStream(w, r, synthReq, segments, encoding, encodeOpts)
+		return
+	}
+
+	// Os tempos exigem a síntese frase a frase
+	subtitles := format == formatSRT || format == formatVTT
+	timed := subtitles || (req.Timings && format != "binary")
+
+	key := h.synthesisKey(&req, synthReq, segments, encoding, encodeOpts, timed)
+	entry, ok := h.audio(w, r, &req, synthReq, segments, timed, encoding, encodeOpts, key)
+	if !ok {
+		return
+	}
+
+	if subtitles {
+		w.Header().Set("Content-Type", subtitleMimeType(format))
+		w.WriteHeader(http.StatusOK)
+		writeSubtitles(w, format, entry.Sentences)
+		return
+	}
+
+	if format == "binary" {
+		// Retornar o áudio binário diretamente
+		w.Header().Set("Content-Type", entry.MimeType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(entry.Data)))
+		w.Header().Set("X-Duration-Seconds", fmt.Sprintf("%.2f", entry.Duration))
+		w.Header().Set("X-Sample-Rate", strconv.Itoa(entry.SampleRate))
+		w.WriteHeader(http.StatusOK)
+		w.Write(entry.Data)
+	} else {
+		// Codificar o áudio em base64 e retornar em JSON
+		encodedAudio := base64.StdEncoding.EncodeToString(entry.Data)
+		response := SynthesizeResponse{
+			Duration:   entry.Duration,
+			Voice:      req.Voice,
+			Text:       req.Text,
+			Audio:      encodedAudio,
+			MimeType:   entry.MimeType,
+			Codec:      entry.Codec,
+			SampleRate: entry.SampleRate,
+			Sentences:  entry.Sentences,
+		}
+		writeJSONResponse(w, http.StatusOK, response)
+	}
+}
+
+// audio retorna o áudio do cache ou o sintetiza e guarda, informando o
+// resultado no cabeçalho X-Cache
+func (h *TTSHandler) audio(w http.ResponseWriter, r *http.Request, req *SynthesizeRequest, synthReq voice.Request, segments []ssml.Segment, timed bool, encoding audio.Encoding, encodeOpts audio.Options, key string) (*cache.Entry, bool) {
+	entry, hit := h.cache.Get(key)
+	if !hit {
+		var ok bool
+		if entry, ok = h.render(w, r, req, synthReq, segments, timed, encoding, encodeOpts); !ok {
+			return nil, false
+		}
+		entry.Key = key
+		if err := h.cache.Put(entry); err != nil {
+			log.Printf("Aviso: %v", err)
+		}
+	}
+	if h.cache.Enabled() {
+		w.Header().Set("X-Cache-Key", key)
+		if hit {
+			w.Header().Set("X-Cache", "HIT")
+		} else {
+			w.Header().Set("X-Cache", "MISS")
+		}
+	}
+	return entry, true
+}
+
+// render sintetiza e codifica o áudio, escrevendo a resposta de erro quando
+// necessário
+func (h *TTSHandler) render(w http.ResponseWriter, r *http.Request, req *SynthesizeRequest, synthReq voice.Request, segments []ssml.Segment, timed bool, encoding audio.Encoding, encodeOpts audio.Options) (*cache.Entry, bool) {
+	var wavData []byte
+	var sentences []timing.Sentence
+	var err error
+	switch {
+	case timed:
+		wavData, sentences, err = ssml.RenderTimed(r.Context(), h.voiceManager, synthReq, segments)
+	case req.TextType == textTypeSSML:
+		wavData, err = ssml.Render(r.Context(), h.voiceManager, synthReq, segments)
+	default:
+		wavData, err = h.voiceManager.Synthesize(r.Context(), synthReq)
+	}
+	var speakerErr *voice.SpeakerError
+	if errors.As(err, &speakerErr) {
+		mensagem := map[string]interface{}{
+			"erro":                 err.Error(),
+			"locutoresDisponiveis": append([]voice.Speaker{}, speakerErr.Available...),
+		}
+		writeJSONResponse(w, http.StatusBadRequest, mensagem)
+		return nil, false
+	}
+	if errors.Is(err, voice.ErrPhonemesUnsupported) {
+		writeJSONError(w, http.StatusNotImplemented, err.Error())
+		return nil, false
+	}
+	if err != nil {
+		voices := h.voiceManager.ListVoices()
+		mensagem := map[string]interface{}{
+			"erro":             err.Error(),
+			"vozesDisponiveis": voices,
+		}
+		writeJSONResponse(w, http.StatusBadRequest, mensagem)
+		return nil, false
+	}
+
+	encoded, err := audio.Encode(wavData, encoding, encodeOpts)
+	if errors.Is(err, audio.ErrCodecUnavailable) {
+		writeJSONError(w, http.StatusNotImplemented, fmt.Sprintf("Encoding %s: %v", encoding, err))
+		return nil, false
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Erro ao codificar o áudio: %v", err))
+		return nil, false
+	}
+
+	return &cache.Entry{
+		Voice:      synthReq.Voice,
+		Data:       encoded.Data,
+		MimeType:   encoded.MimeType,
+		Codec:      encoded.Codec,
+		Duration:   encoded.Duration,
+		SampleRate: encoded.SampleRate,
+		Sentences:  sentences,
+	}, true
+}
+
+// writeSubtitles escreve as legendas das frases no formato informado
+func writeSubtitles(w io.Writer, format string, sentences []timing.Sentence) error {
+	if format == formatSRT {
+		return timing.WriteSRT(w, sentences)
+	}
+	return timing.WriteVTT(w, sentences)
+}
+
+// subtitleMimeType retorna o Content-Type do formato de legenda
+func subtitleMimeType(format string) string {
+	if format == formatSRT {
+		return "application/x-subrip; charset=utf-8"
+	}
+	return "text/vtt; charset=utf-8"
+}
+
+// validateRequest valida o texto, a voz e a prosódia da requisição,
+// escrevendo a resposta de erro quando necessário
+func (h *TTSHandler) validateRequest(w http.ResponseWriter, req *SynthesizeRequest) (voice.Options, bool) {
 	// Validações adicionais
 	if req.Text == "" {
 		writeJSONError(w, http.StatusBadRequest, "Texto não pode estar vazio")
-		return
+		return voice.Options{}, false
 	}
 
 	// Validação do tamanho do texto
@@ -63,7 +349,7 @@ func (h *TTSHandler) Synthesize(w http.ResponseWriter, r *http.Request) {
 			"tamanhoTexto": len(req.Text),
 		}
 		writeJSONResponse(w, http.StatusBadRequest, mensagem)
-		return
+		return voice.Options{}, false
 	}
 
 	if req.Voice == "" {
@@ -73,56 +359,113 @@ func (h *TTSHandler) Synthesize(w http.ResponseWriter, r *http.Request) {
 			"vozesDisponiveis": voices,
 		}
 		writeJSONResponse(w, http.StatusBadRequest, mensagem)
-		return
+		return voice.Options{}, false
 	}
 
-	// Obter o formato solicitado
-	format := r.URL.Query().Get("format")
-	if format == "" {
-		format = "base64" // Padrão é base64
+	opts, err := req.prosodyOptions()
+	if err != nil {
+		writeOptionsError(w, err)
+		return opts, false
+	}
+	return opts, true
+}
+
+// segments divide o texto em segmentos de síntese: frases para texto simples
+// ou o resultado da interpretação do SSML
+func (h *TTSHandler) segments(w http.ResponseWriter, req *SynthesizeRequest) ([]ssml.Segment, bool) {
+	switch req.TextType {
+	case "", textTypeText:
+		return ssml.FromText(req.Text), true
+	case textTypePhonemes:
+		// Fonemas não passam pela divisão em frases
+		return []ssml.Segment{{Text: req.Text, Rate: 1}}, true
+	case textTypeSSML:
+	default:
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("text_type inválido: %q (use text, ssml ou phonemes)", req.TextType))
+		return nil, false
 	}
 
-	audio, err := h.voiceManager.Synthesize(req.Text, req.Voice)
+	var language string
+	if info, exists := h.voiceManager.Voice(req.Voice); exists {
+		language = info.Language
+	}
+	segments, err := ssml.Parse(req.Text, language)
+	var parseErr *ssml.ParseError
+	if errors.As(err, &parseErr) {
+		writeJSONResponse(w, http.StatusBadRequest, SSMLErrorResponse{
+			Erro:    parseErr.Error(),
+			Linha:   parseErr.Line,
+			Coluna:  parseErr.Column,
+			Posicao: parseErr.Offset,
+		})
+		return nil, false
+	}
 	if err != nil {
-		voices := h.voiceManager.ListVoices()
-		mensagem := map[string]interface{}{
-			"erro":             err.Error(),
-			"vozesDisponiveis": voices,
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	for _, name := range ssml.Voices(segments) {
+		if !h.voiceManager.HasVoice(name) {
+			writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+				"erro":             fmt.Sprintf("Voz %s usada em <voice> não encontrada", name),
+				"vozesDisponiveis": h.voiceManager.ListVoices(),
+			})
+			return nil, false
 		}
-		writeJSONResponse(w, http.StatusBadRequest, mensagem)
-		return
 	}
+	return segments, true
+}
 
-	// Calcular a duração do áudio
-	duration, err := calculateWavDuration(audio)
-	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Erro ao calcular a duração do áudio: %v", err))
-		return
+// outputOptions determina o codec de saída a partir do corpo, da query
+// string ou do cabeçalho Accept, e valida os parâmetros do codec
+func (h *TTSHandler) outputOptions(r *http.Request, req *SynthesizeRequest, format string) (audio.Encoding, audio.Options, error) {
+	cfg := h.voiceManager.Config
+	opts := audio.Options{
+		Opus: audio.OpusOptions{Bitrate: cfg.OpusBitrate, SampleRate: cfg.OpusSampleRate},
+		MP3:  audio.MP3Options{Bitrate: cfg.MP3Bitrate},
 	}
 
-	if format == "binary" {
-		// Retornar o áudio binário diretamente
-		w.Header().Set("Content-Type", "audio/wav")
-		w.Header().Set("Content-Length", strconv.Itoa(len(audio)))
-		w.Header().Set("X-Duration-Seconds", fmt.Sprintf("%.2f", duration))
-		w.WriteHeader(http.StatusOK)
-		w.Write(audio)
-	} else {
-		// Codificar o áudio em base64 e retornar em JSON
-		encodedAudio := base64.StdEncoding.EncodeToString(audio)
-		response := map[string]interface{}{
-			"duration": duration,
-			"voice":    req.Voice,
-			"text":     req.Text,
-			"audio":    encodedAudio,
+	value := req.Encoding
+	if value == "" {
+		value = r.URL.Query().Get("encoding")
+	}
+
+	encoding := audio.EncodingWAV
+	if value != "" {
+		var err error
+		if encoding, err = audio.ParseEncoding(value); err != nil {
+			return "", opts, err
 		}
-		writeJSONResponse(w, http.StatusOK, response)
+	} else if format == "binary" {
+		if enc, ok := audio.EncodingFromAccept(r.Header.Get("Accept")); ok {
+			encoding = enc
+		}
+	}
+
+	if req.SampleRate != nil {
+		opts.SampleRate = *req.SampleRate
+		opts.Opus.SampleRate = *req.SampleRate
+	}
+
+	// O bitrate mp3 aceito depende da taxa de saída e, portanto, da voz
+	var source int
+	if info, exists := h.voiceManager.Voice(req.Voice); exists {
+		source = info.SampleRate
+	}
+	if req.Bitrate != nil {
+		opts.Opus.Bitrate = *req.Bitrate
+		opts.MP3.Bitrate = *req.Bitrate
+	} else {
+		opts.FitMP3Bitrate(encoding, source)
 	}
+
+	return encoding, opts, opts.ValidateFor(encoding, source)
 }
 
 // ListVoices retorna a lista de vozes disponíveis
 // @Summary      Lista as vozes disponíveis
-// @Description  Retorna uma lista das vozes disponíveis para síntese
+// @Description  Retorna uma lista das vozes disponíveis para síntese e os locutores de cada voz multi-locutor
 // @Tags         TTS
 // @Produce      json
 // @Success      200  {object}  handlers.ListVoicesResponse
@@ -136,35 +479,16 @@ func (h *TTSHandler) ListVoices(w http.ResponseWriter, r *http.Request) {
 	}
 
 	voices := h.voiceManager.ListVoices()
-	writeJSONResponse(w, http.StatusOK, map[string][]string{"voices": voices})
-}
-
-// Função para calcular a duração do áudio em segundos
-func calculateWavDuration(data []byte) (float64, error) {
-	if len(data) < 44 {
-		return 0, fmt.Errorf("dados insuficientes para um arquivo WAV válido")
+	response := ListVoicesResponse{
+		Voices:   voices,
+		Speakers: make(map[string][]voice.Speaker),
 	}
-
-	// Ler o número de canais (2 bytes a partir do byte 22)
-	numChannels := binary.LittleEndian.Uint16(data[22:24])
-
-	// Ler a taxa de amostragem (4 bytes a partir do byte 24)
-	sampleRate := binary.LittleEndian.Uint32(data[24:28])
-
-	// Ler bits por amostra (2 bytes a partir do byte 34)
-	bitsPerSample := binary.LittleEndian.Uint16(data[34:36])
-
-	// Calcular o tamanho dos dados de áudio (tamanho total menos o header de 44 bytes)
-	dataSize := len(data) - 44
-
-	// Calcular o número total de amostras
-	bytesPerSample := bitsPerSample / 8
-	totalSamples := uint32(dataSize) / uint32(bytesPerSample*numChannels)
-
-	// Calcular a duração
-	duration := float64(totalSamples) / float64(sampleRate)
-
-	return duration, nil
+	for _, name := range voices {
+		if info, exists := h.voiceManager.Voice(name); exists && len(info.Speakers) > 0 {
+			response.Speakers[name] = info.Speakers
+		}
+	}
+	writeJSONResponse(w, http.StatusOK, response)
 }
 
 // Função auxiliar para escrever respostas JSON
@@ -179,12 +503,46 @@ func writeJSONError(w http.ResponseWriter, statusCode int, message string) {
 	writeJSONResponse(w, statusCode, map[string]string{"erro": message})
 }
 
+// writeOptionsError escreve um erro de validação dos parâmetros de prosódia,
+// incluindo o intervalo permitido quando disponível
+func writeOptionsError(w http.ResponseWriter, err error) {
+	var rangeErr *voice.RangeError
+	if !errors.As(err, &rangeErr) {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSONResponse(w, http.StatusBadRequest, RangeErrorResponse{
+		Erro:   "Parâmetro fora do intervalo permitido",
+		Campo:  rangeErr.Field,
+		Valor:  rangeErr.Value,
+		Minimo: rangeErr.Range.Min,
+		Maximo: rangeErr.Range.Max,
+	})
+}
+
 // SynthesizeResponse representa a resposta de sucesso da síntese
 type SynthesizeResponse struct {
-	Duration float64 `json:"duration"`
-	Voice    string  `json:"voice"`
-	Text     string  `json:"text"`
-	Audio    string  `json:"audio,omitempty"`
+	Duration   float64 `json:"duration"`
+	Voice      string  `json:"voice"`
+	Text       string  `json:"text"`
+	Audio      string  `json:"audio,omitempty"`
+	MimeType   string  `json:"mime_type"`
+	Codec      string  `json:"codec"`
+	SampleRate int     `json:"sample_rate"`
+
+	// Intervalos de fala em segundos, presentes com timings=true. Os tempos
+	// das frases são medidos no áudio; os das palavras são estimados dentro
+	// de cada frase e marcados com estimated.
+	Sentences []timing.Sentence `json:"sentences,omitempty"`
+}
+
+// SSMLErrorResponse indica SSML malformado e a posição do erro
+type SSMLErrorResponse struct {
+	Erro    string `json:"erro"`
+	Linha   int    `json:"linha"`
+	Coluna  int    `json:"coluna"`
+	Posicao int64  `json:"posicao"` // deslocamento em bytes
 }
 
 // ErrorResponse representa uma resposta de erro
@@ -192,6 +550,16 @@ type ErrorResponse struct {
 	Erro string `json:"erro"`
 }
 
+// RangeErrorResponse representa um parâmetro fora do intervalo permitido
+type RangeErrorResponse struct {
+	Erro   string  `json:"erro"`
+	Campo  string  `json:"campo"`
+	Valor  float64 `json:"valor"`
+	Minimo float64 `json:"minimo"`
+	Maximo float64 `json:"maximo"`
+}
+
 type ListVoicesResponse struct {
-	Voices []string `json:"voices"`
+	Voices   []string                   `json:"voices"`
+	Speakers map[string][]voice.Speaker `json:"speakers"` // Locutores por voz, apenas para modelos multi-locutor
 }
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"tts-api/internal/audio"
+	"tts-api/internal/audio/wav"
+	"tts-api/internal/config"
+	"tts-api/internal/lexicon"
+	"tts-api/internal/voice"
+)
+
+func newTestHandler(t *testing.T) *TTSHandler {
+	t.Helper()
+	cfg := config.Load()
+	cfg.VoicesDir = t.TempDir()
+	cfg.LexiconDir = ""
+	cfg.VoicesWatch = "off"
+	cfg.MaxTexto = 40
+
+	vm, err := voice.NewManagerWithEngine(cfg, voice.NewFakeEngine("fake"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { vm.Close() })
+	return NewTTSHandler(vm, nil)
+}
+
+func synthesize(t *testing.T, h *TTSHandler, query string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/synthesize"+query, bytes.NewReader(data))
+	w := httptest.NewRecorder()
+	h.Synthesize(w, r)
+	return w
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func decodeBody(t *testing.T, w *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("resposta não é JSON: %v (%q)", err, w.Body.String())
+	}
+	return body
+}
+
+func TestSynthesizeValidation(t *testing.T) {
+	h := newTestHandler(t)
+
+	tests := []struct {
+		name   string
+		body   SynthesizeRequest
+		fields []string
+	}{
+		{"texto vazio", SynthesizeRequest{Voice: "fake"}, []string{"erro"}},
+		{"voz ausente", SynthesizeRequest{Text: "Olá"}, []string{"erro", "vozesDisponiveis"}},
+		{"voz desconhecida", SynthesizeRequest{Text: "Olá", Voice: "inexistente"}, []string{"erro", "vozesDisponiveis"}},
+		{"texto longo", SynthesizeRequest{Text: strings.Repeat("a", 41), Voice: "fake"}, []string{"erro", "limite", "tamanhoTexto"}},
+		// A voz fake gera 22050 Hz, onde o MPEG-2 limita o mp3 a 160 kbps
+		{"bitrate mp3 acima do limite", SynthesizeRequest{Text: "Olá", Voice: "fake", Encoding: "mp3", Bitrate: intPtr(192000)}, []string{"erro"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := synthesize(t, h, "", tt.body)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status %d, esperado 400: %s", w.Code, w.Body.String())
+			}
+			body := decodeBody(t, w)
+			for _, field := range tt.fields {
+				if _, ok := body[field]; !ok {
+					t.Errorf("campo %q ausente em %v", field, body)
+				}
+			}
+			if voices, ok := body["vozesDisponiveis"].([]any); ok && (len(voices) != 1 || voices[0] != "fake") {
+				t.Errorf("vozesDisponiveis = %v, esperado [fake]", voices)
+			}
+			if limit, ok := body["limite"]; ok && limit != float64(40) {
+				t.Errorf("limite = %v, esperado 40", limit)
+			}
+		})
+	}
+}
+
+func TestSynthesizeMaxTexto(t *testing.T) {
+	h := newTestHandler(t)
+
+	// O limite é inclusivo
+	if w := synthesize(t, h, "", SynthesizeRequest{Text: strings.Repeat("a", 40), Voice: "fake"}); w.Code != http.StatusOK {
+		t.Fatalf("status %d no limite, esperado 200: %s", w.Code, w.Body.String())
+	}
+	if w := synthesize(t, h, "", SynthesizeRequest{Text: strings.Repeat("a", 41), Voice: "fake"}); w.Code != http.StatusBadRequest {
+		t.Fatalf("status %d acima do limite, esperado 400", w.Code)
+	}
+}
+
+func TestSynthesizeFormats(t *testing.T) {
+	h := newTestHandler(t)
+	req := SynthesizeRequest{Text: "Olá mundo.", Voice: "fake"}
+
+	w := synthesize(t, h, "", req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("base64: status %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("base64: Content-Type %q", ct)
+	}
+	var resp SynthesizeResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := base64.StdEncoding.DecodeString(resp.Audio)
+	if err != nil {
+		t.Fatalf("áudio não é base64: %v", err)
+	}
+	if resp.Voice != "fake" || resp.MimeType != "audio/wav" || resp.Duration <= 0 {
+		t.Errorf("resposta inesperada: %+v", resp)
+	}
+
+	w = synthesize(t, h, "?format=binary", req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("binary: status %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "audio/wav" {
+		t.Fatalf("binary: Content-Type %q", ct)
+	}
+	if w.Header().Get("X-Duration-Seconds") == "" || w.Header().Get("X-Sample-Rate") == "" {
+		t.Errorf("binary: cabeçalhos de duração ausentes: %v", w.Header())
+	}
+	if !bytes.Equal(w.Body.Bytes(), encoded) {
+		t.Errorf("binary difere do áudio em base64 (%d e %d bytes)", w.Body.Len(), len(encoded))
+	}
+	if _, _, err := wav.Parse(w.Body.Bytes()); err != nil {
+		t.Errorf("binary não é um WAV válido: %v", err)
+	}
+}
+
+func TestSynthesisKeyLexicon(t *testing.T) {
+	h := newTestHandler(t)
+
+	for _, textType := range []string{textTypeText, textTypeSSML} {
+		req := SynthesizeRequest{Text: "<speak>O SUS</speak>", Voice: "fake", TextType: textType}
+		if textType == textTypeText {
+			req.Text = "O SUS"
+		}
+		key := func() string {
+			synthReq := voice.Request{Voice: req.Voice, Text: req.Text}
+			return h.synthesisKey(&req, synthReq, nil, "wav", audio.Options{}, false)
+		}
+
+		before := key()
+		if _, err := h.voiceManager.Lexicons().Set("fake", lexicon.Entry{Word: "SUS", Alias: "sus " + textType}); err != nil {
+			t.Fatal(err)
+		}
+		if after := key(); after == before {
+			t.Errorf("%s: chave não mudou após alterar o léxico", textType)
+		}
+	}
+}
+
+func TestNormalizeUsesPrepareText(t *testing.T) {
+	h := newTestHandler(t)
+	if _, err := h.voiceManager.Lexicons().Set("fake", lexicon.Entry{Word: "SUS", Alias: "sus"}, lexicon.Entry{Word: "H2O", Phonemes: "aˈɡa 2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := json.Marshal(NormalizeRequest{Text: "O SUS tem 2 H2O", Voice: "fake", Language: "pt_BR"})
+	w := httptest.NewRecorder()
+	h.Normalize(w, httptest.NewRequest(http.MethodPost, "/normalize", bytes.NewReader(data)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+	var resp NormalizeResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	// Os fonemas do léxico não são normalizados
+	if want := "O sus tem dois [[ aˈɡa 2 ]]"; resp.Normalized != want {
+		t.Errorf("normalizado %q, esperado %q", resp.Normalized, want)
+	}
+}
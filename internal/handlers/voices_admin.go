@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"tts-api/internal/voice"
+	"tts-api/internal/voice/downloader"
+	"tts-api/internal/voice/installer"
+)
+
+type VoicesAdminHandler struct {
+	voiceManager *voice.Manager
+	catalog      *downloader.Catalog
+	installer    *installer.Installer
+}
+
+func NewVoicesAdminHandler(vm *voice.Manager, catalog *downloader.Catalog, inst *installer.Installer) *VoicesAdminHandler {
+	return &VoicesAdminHandler{voiceManager: vm, catalog: catalog, installer: inst}
+}
+
+// CatalogVoice é uma voz do manifesto do piper
+type CatalogVoice struct {
+	Key       string                  `json:"key"`
+	Name      string                  `json:"name"`
+	Language  downloader.LanguageInfo `json:"language"`
+	Quality   string                  `json:"quality"`
+	SizeBytes int64                   `json:"size_bytes"`
+	Installed bool                    `json:"installed"`
+}
+
+// CatalogResponse traz as vozes do catálogo que atendem aos filtros
+type CatalogResponse struct {
+	Voices []CatalogVoice `json:"voices"`
+	Total  int            `json:"total"`
+}
+
+// InstallVoiceRequest identifica a voz no manifesto
+type InstallVoiceRequest struct {
+	Key string `json:"key" example:"pt_BR-faber-medium"`
+}
+
+// ListTasksResponse traz as instalações e atualizações recentes
+type ListTasksResponse struct {
+	Tasks []installer.Task `json:"tasks"`
+}
+
+// Catalog lista as vozes disponíveis para instalação
+// @Summary      Consulta o catálogo de vozes
+// @Description  Lista as vozes do manifesto do piper (VOICES_MANIFEST_URL), filtradas por idioma, qualidade ou texto. O manifesto fica em memória por VOICES_CATALOG_TTL_MINUTES.
+// @Tags         Administração
+// @Produce      json
+// @Param        q query string false "Texto buscado na chave, no nome e no idioma"
+// @Param        language query string false "Código (pt_BR) ou família (pt) do idioma"
+// @Param        quality query string false "Qualidade" Enums(x_low, low, medium, high)
+// @Param        refresh query bool false "Baixa o manifesto novamente"
+// @Success      200  {object}  handlers.CatalogResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      502  {object}  handlers.ErrorResponse
+// @Router       /admin/catalog [get]
+// @Security     ApiKeyAuth
+func (h *VoicesAdminHandler) Catalog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	refresh, err := boolParam(query, "refresh")
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	manifest, err := h.catalog.Manifest(r.Context(), refresh != nil && *refresh)
+	if err != nil {
+		writeJSONError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	voices := make([]CatalogVoice, 0)
+	for _, v := range manifest.Search(query.Get("q"), query.Get("language"), query.Get("quality")) {
+		voices = append(voices, CatalogVoice{
+			Key:       v.Key,
+			Name:      v.Name,
+			Language:  v.Language,
+			Quality:   v.Quality,
+			SizeBytes: v.Size(),
+			Installed: h.voiceManager.HasVoice(v.Name),
+		})
+	}
+	writeJSONResponse(w, http.StatusOK, CatalogResponse{Voices: voices, Total: len(voices)})
+}
+
+// Install inicia a instalação de uma voz do catálogo
+// @Summary      Instala uma voz
+// @Description  Baixa a voz do catálogo em segundo plano, confere o tamanho e o MD5 dos arquivos e a registra sem reiniciar o serviço. Acompanhe o progresso em GET /admin/tasks/{id}.
+// @Tags         Administração
+// @Accept       json
+// @Produce      json
+// @Param        InstallVoiceRequest body handlers.InstallVoiceRequest true "Chave da voz no manifesto"
+// @Success      202  {object}  installer.Task
+// @Failure      400  {object}  handlers.ErrorResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      409  {object}  handlers.ErrorResponse
+// @Failure      502  {object}  handlers.ErrorResponse
+// @Router       /admin/voices [post]
+// @Security     ApiKeyAuth
+func (h *VoicesAdminHandler) Install(w http.ResponseWriter, r *http.Request) {
+	var req InstallVoiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Erro ao ler requisição")
+		return
+	}
+	if req.Key == "" {
+		writeJSONError(w, http.StatusBadRequest, "key não pode estar vazio")
+		return
+	}
+
+	task, err := h.installer.Install(r.Context(), req.Key)
+	if err != nil {
+		writeInstallerError(w, err)
+		return
+	}
+	w.Header().Set("Location", "/admin/tasks/"+task.ID)
+	writeJSONResponse(w, http.StatusAccepted, task)
+}
+
+// Update inicia a atualização de uma voz instalada
+// @Summary      Atualiza uma voz
+// @Description  Compara os arquivos da voz com o manifesto atual e, se diferirem, baixa a nova versão em segundo plano. A versão anterior atende as requisições até a troca.
+// @Tags         Administração
+// @Produce      json
+// @Param        name path string true "Nome da voz"
+// @Success      202  {object}  installer.Task
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Failure      409  {object}  handlers.ErrorResponse
+// @Router       /admin/voices/{name}/update [post]
+// @Security     ApiKeyAuth
+func (h *VoicesAdminHandler) Update(w http.ResponseWriter, r *http.Request) {
+	task, err := h.installer.Update(r.PathValue("name"))
+	if err != nil {
+		writeInstallerError(w, err)
+		return
+	}
+	w.Header().Set("Location", "/admin/tasks/"+task.ID)
+	writeJSONResponse(w, http.StatusAccepted, task)
+}
+
+// Remove desinstala uma voz
+// @Summary      Remove uma voz
+// @Description  Remove a voz da lista e apaga os arquivos. As requisições em andamento são concluídas.
+// @Tags         Administração
+// @Param        name path string true "Nome da voz"
+// @Success      204
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Failure      409  {object}  handlers.ErrorResponse
+// @Router       /admin/voices/{name} [delete]
+// @Security     ApiKeyAuth
+func (h *VoicesAdminHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	if err := h.installer.Remove(r.PathValue("name")); err != nil {
+		writeInstallerError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTasks lista as instalações e atualizações recentes
+// @Summary      Lista as tarefas de instalação
+// @Tags         Administração
+// @Produce      json
+// @Success      200  {object}  handlers.ListTasksResponse
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Router       /admin/tasks [get]
+// @Security     ApiKeyAuth
+func (h *VoicesAdminHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	writeJSONResponse(w, http.StatusOK, ListTasksResponse{Tasks: h.installer.Tasks()})
+}
+
+// GetTask retorna o estado de uma instalação ou atualização
+// @Summary      Consulta uma tarefa de instalação
+// @Description  Retorna o estado e o progresso do download (bytes baixados e total)
+// @Tags         Administração
+// @Produce      json
+// @Param        id path string true "Id da tarefa"
+// @Success      200  {object}  installer.Task
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      404  {object}  handlers.ErrorResponse
+// @Router       /admin/tasks/{id} [get]
+// @Security     ApiKeyAuth
+func (h *VoicesAdminHandler) GetTask(w http.ResponseWriter, r *http.Request) {
+	task, exists := h.installer.Task(r.PathValue("id"))
+	if !exists {
+		writeJSONError(w, http.StatusNotFound, "Tarefa não encontrada")
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, task)
+}
+
+// writeInstallerError converte os erros do installer no status HTTP
+func writeInstallerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, installer.ErrUnknownKey):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, installer.ErrNotInstalled):
+		writeJSONError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, installer.ErrInstalled), errors.Is(err, installer.ErrBusy):
+		writeJSONError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, downloader.ErrManifest):
+		writeJSONError(w, http.StatusBadGateway, err.Error())
+	default:
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,462 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"tts-api/internal/audio"
+	"tts-api/internal/text"
+	"tts-api/internal/voice"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// WSSubprotocol é o subprotocolo anunciado pelo servidor; clientes que
+	// enviam o token via subprotocolo devem incluí-lo junto com "bearer.<token>"
+	WSSubprotocol = "gotts"
+
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 16384,
+}
+
+// WSMessage é uma mensagem enviada pelo cliente na sessão WebSocket.
+// Type pode ser "start" (abre a sessão com voz e formato), "text" (acrescenta
+// um trecho de texto), "flush" (sintetiza o texto pendente) ou "cancel"
+// (descarta o que ainda não foi sintetizado).
+type WSMessage struct {
+	Type string `json:"type"`
+	SynthesizeRequest
+}
+
+// WSEvent é um evento JSON enviado ao cliente; o áudio segue em mensagens
+// binárias entre sentence_started e sentence_finished
+type WSEvent struct {
+	Type       string  `json:"type"`
+	Index      *int    `json:"index,omitempty"`
+	Text       string  `json:"text,omitempty"`
+	Voice      string  `json:"voice,omitempty"`
+	Encoding   string  `json:"encoding,omitempty"`
+	MimeType   string  `json:"mime_type,omitempty"`
+	SampleRate int     `json:"sample_rate,omitempty"`
+	Duration   float64 `json:"duration,omitempty"`
+	Erro       string  `json:"erro,omitempty"`
+}
+
+// SynthesizeWS abre uma sessão interativa de síntese via WebSocket
+// @Summary      Sessão de síntese via WebSocket
+// @Description  Recebe texto em partes e devolve o áudio de cada frase assim que fica pronto. Mensagens do cliente: {"type":"start", ...campos de /synthesize}, {"type":"text","text":"..."}, {"type":"flush"} e {"type":"cancel"}. Eventos do servidor: started, sentence_started, sentence_finished, flushed, cancelled e error, com o áudio em mensagens binárias (wav, pcm_s16le, mulaw ou alaw). O token pode ser enviado no cabeçalho Authorization, no parâmetro token ou no subprotocolo "bearer.<token>", sempre junto com "gotts", o único subprotocolo confirmado pelo servidor. Navegadores só conectam a partir da mesma origem ou das origens em WS_ALLOWED_ORIGINS.
+// @Tags         TTS
+// @Param        token query string false "Token de autenticação (alternativa ao cabeçalho Authorization)"
+// @Success      101
+// @Failure      401  {object}  handlers.ErrorResponse
+// @Failure      403  {object}  handlers.ErrorResponse
+// @Router       /ws/synthesize [get]
+// @Security     ApiKeyAuth
+func (h *TTSHandler) SynthesizeWS(w http.ResponseWriter, r *http.Request) {
+	if !websocket.IsWebSocketUpgrade(r) {
+		writeJSONError(w, http.StatusBadRequest, "Requisição WebSocket esperada")
+		return
+	}
+	if !h.checkOrigin(r) {
+		writeJSONError(w, http.StatusForbidden, "Origem não permitida")
+		return
+	}
+
+	// Os navegadores exigem que a resposta confirme um dos subprotocolos
+	// oferecidos. Apenas WSSubprotocol é confirmado, para nunca devolver o
+	// token enviado em "bearer.<token>".
+	var header http.Header
+	for _, p := range websocket.Subprotocols(r) {
+		if p == WSSubprotocol {
+			header = http.Header{"Sec-Websocket-Protocol": {WSSubprotocol}}
+		}
+	}
+
+	upgrader := wsUpgrader
+	upgrader.CheckOrigin = h.checkOrigin
+	conn, err := upgrader.Upgrade(w, r, header)
+	if err != nil {
+		// O upgrader já respondeu ao cliente
+		return
+	}
+
+	s := newWSSession(h, r, conn)
+	s.run()
+}
+
+// checkOrigin aceita conexões sem Origin (clientes fora do navegador), da
+// mesma origem ou das origens em WS_ALLOWED_ORIGINS, para que páginas de
+// outros sites não abram sessões em nome do usuário
+func (h *TTSHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range h.voiceManager.Config.WSAllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// wsItem é uma unidade de trabalho da sessão: uma frase ou um pedido de flush
+type wsItem struct {
+	sentence   string
+	flush      bool
+	generation int
+}
+
+type wsSession struct {
+	h       *TTSHandler
+	upgrade *http.Request
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+
+	// Configuração definida pela mensagem start
+	req     voice.Request
+	encoder *audio.StreamEncoder
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu         sync.Mutex
+	queue      []wsItem
+	notify     chan struct{}
+	pending    string // texto ainda sem final de frase
+	index      int
+	generation int
+	genCtx     context.Context
+	genCancel  context.CancelFunc
+	duration   float64
+}
+
+func newWSSession(h *TTSHandler, r *http.Request, conn *websocket.Conn) *wsSession {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &wsSession{
+		h:       h,
+		upgrade: r,
+		conn:    conn,
+		ctx:     ctx,
+		cancel:  cancel,
+		notify:  make(chan struct{}, 1),
+	}
+	s.genCtx, s.genCancel = context.WithCancel(ctx)
+	return s
+}
+
+// run processa as mensagens do cliente até a conexão ser encerrada
+func (s *wsSession) run() {
+	defer s.conn.Close()
+	defer s.cancel()
+
+	go s.synthesizeLoop()
+	go s.keepAlive()
+
+	s.conn.SetReadLimit(int64(s.h.voiceManager.Config.MaxTexto) + 4096)
+	s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		messageType, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("Sessão WebSocket encerrada: %v", err)
+			}
+			return
+		}
+		if messageType != websocket.TextMessage {
+			s.sendError(nil, "Apenas mensagens JSON de texto são aceitas")
+			continue
+		}
+
+		var msg WSMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.sendError(nil, "Erro ao ler mensagem")
+			continue
+		}
+
+		switch msg.Type {
+		case "start":
+			s.start(&msg.SynthesizeRequest)
+		case "text":
+			s.appendText(msg.Text)
+		case "flush":
+			s.flush()
+		case "cancel":
+			s.cancelPending()
+		default:
+			s.sendError(nil, fmt.Sprintf("Tipo de mensagem desconhecido: %q", msg.Type))
+		}
+	}
+}
+
+// start valida a voz e o formato e prepara a sessão para receber texto
+func (s *wsSession) start(req *SynthesizeRequest) {
+	if s.encoder != nil {
+		s.sendError(nil, "Sessão já iniciada")
+		return
+	}
+
+	if req.TextType != "" && req.TextType != textTypeText {
+		s.sendError(nil, "Sessões WebSocket aceitam apenas texto simples")
+		return
+	}
+
+	vm := s.h.voiceManager
+	info, exists := vm.Voice(req.Voice)
+	if !exists {
+		s.sendError(nil, fmt.Sprintf("Voz %q não encontrada; vozes disponíveis: %s", req.Voice, strings.Join(vm.ListVoices(), ", ")))
+		return
+	}
+	if req.Speaker != "" {
+		if _, err := info.ResolveSpeaker(string(req.Speaker)); err != nil {
+			s.sendError(nil, err.Error())
+			return
+		}
+	}
+
+	opts, err := req.prosodyOptions()
+	if err != nil {
+		s.sendError(nil, err.Error())
+		return
+	}
+	encoding, encodeOpts, err := s.h.outputOptions(s.upgrade, req, "binary")
+	if err != nil {
+		s.sendError(nil, err.Error())
+		return
+	}
+	encoder, err := audio.NewStreamEncoder(encoding, encodeOpts)
+	if err != nil {
+		s.sendError(nil, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.req = voice.Request{Voice: req.Voice, Speaker: string(req.Speaker), Options: opts, SkipNormalization: req.skipNormalization()}
+	s.encoder = encoder
+	s.mu.Unlock()
+
+	s.send(WSEvent{
+		Type:       "started",
+		Voice:      req.Voice,
+		Encoding:   string(encoding),
+		MimeType:   encoding.MimeType(),
+		SampleRate: audio.OutputSampleRate(encoding, encodeOpts, info.SampleRate),
+	})
+
+	if req.Text != "" {
+		s.appendText(req.Text)
+	}
+}
+
+// appendText acumula o texto recebido e enfileira as frases completas
+func (s *wsSession) appendText(fragment string) {
+	if s.encoder == nil {
+		s.sendError(nil, "Envie uma mensagem start antes do texto")
+		return
+	}
+
+	s.mu.Lock()
+	sentences, rest := text.SplitComplete(s.pending + fragment)
+	if len(rest) > s.h.voiceManager.Config.MaxTexto {
+		s.pending = ""
+		s.mu.Unlock()
+		s.sendError(nil, fmt.Sprintf("Texto pendente sem final de frase excede o limite de %d caracteres", s.h.voiceManager.Config.MaxTexto))
+		return
+	}
+	s.pending = rest
+	for _, sentence := range sentences {
+		s.enqueueLocked(wsItem{sentence: sentence})
+	}
+	s.mu.Unlock()
+}
+
+// flush enfileira o texto pendente mesmo sem final de frase e, ao final da
+// fila, envia o evento flushed
+func (s *wsSession) flush() {
+	if s.encoder == nil {
+		s.sendError(nil, "Envie uma mensagem start antes do flush")
+		return
+	}
+
+	s.mu.Lock()
+	if sentence := strings.TrimSpace(s.pending); sentence != "" {
+		s.enqueueLocked(wsItem{sentence: sentence})
+	}
+	s.pending = ""
+	s.enqueueLocked(wsItem{flush: true})
+	s.mu.Unlock()
+}
+
+// cancelPending interrompe a frase em síntese e descarta o restante
+func (s *wsSession) cancelPending() {
+	s.mu.Lock()
+	s.genCancel()
+	s.generation++
+	s.genCtx, s.genCancel = context.WithCancel(s.ctx)
+	s.queue = nil
+	s.pending = ""
+	s.duration = 0
+	s.mu.Unlock()
+
+	s.send(WSEvent{Type: "cancelled"})
+}
+
+func (s *wsSession) enqueueLocked(item wsItem) {
+	item.generation = s.generation
+	s.queue = append(s.queue, item)
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// next aguarda o próximo item da fila junto com o contexto da sua geração
+func (s *wsSession) next() (wsItem, context.Context, bool) {
+	for {
+		s.mu.Lock()
+		if len(s.queue) > 0 {
+			item := s.queue[0]
+			s.queue = s.queue[1:]
+			ctx := s.genCtx
+			s.mu.Unlock()
+			return item, ctx, true
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.notify:
+		case <-s.ctx.Done():
+			return wsItem{}, nil, false
+		}
+	}
+}
+
+// synthesizeLoop sintetiza as frases na ordem em que foram recebidas
+func (s *wsSession) synthesizeLoop() {
+	for {
+		item, ctx, ok := s.next()
+		if !ok {
+			return
+		}
+
+		if item.flush {
+			s.mu.Lock()
+			current := item.generation == s.generation
+			duration := s.duration
+			s.duration = 0
+			s.mu.Unlock()
+			if current {
+				s.send(WSEvent{Type: "flushed", Duration: duration})
+			}
+			continue
+		}
+
+		s.mu.Lock()
+		index := s.index
+		s.index++
+		req := s.req
+		s.mu.Unlock()
+		req.Text = item.sentence
+
+		s.send(WSEvent{Type: "sentence_started", Index: &index, Text: item.sentence})
+
+		data, err := s.h.voiceManager.Synthesize(ctx, req)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.sendError(&index, err.Error())
+			}
+			continue
+		}
+
+		// Descarta o áudio de uma frase cancelada enquanto era sintetizada.
+		// A verificação precede a codificação porque o codificador guarda
+		// estado: o primeiro trecho leva o cabeçalho WAV.
+		var chunk []byte
+		var seconds float64
+		s.mu.Lock()
+		current := item.generation == s.generation
+		if current {
+			if chunk, seconds, err = s.encoder.Encode(data); err == nil {
+				s.duration += seconds
+			}
+		}
+		s.mu.Unlock()
+		if !current {
+			continue
+		}
+		if err != nil {
+			s.sendError(&index, err.Error())
+			continue
+		}
+
+		if err := s.write(websocket.BinaryMessage, chunk); err != nil {
+			return
+		}
+		s.send(WSEvent{Type: "sentence_finished", Index: &index, Duration: seconds})
+	}
+}
+
+// keepAlive envia pings periódicos para detectar conexões abandonadas
+func (s *wsSession) keepAlive() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.write(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *wsSession) send(event WSEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	s.write(websocket.TextMessage, data)
+}
+
+func (s *wsSession) sendError(index *int, message string) {
+	s.send(WSEvent{Type: "error", Index: index, Erro: message})
+}
+
+// write serializa as escritas, já que a conexão aceita apenas um escritor
+func (s *wsSession) write(messageType int, data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	err := s.conn.WriteMessage(messageType, data)
+	if err != nil && !errors.Is(err, websocket.ErrCloseSent) {
+		s.cancel()
+	}
+	return err
+}
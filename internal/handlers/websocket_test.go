@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestSynthesizeWSOrigin(t *testing.T) {
+	h := newTestHandler(t)
+	h.voiceManager.Config.WSAllowedOrigins = []string{"https://app.exemplo.com/"}
+	server := httptest.NewServer(http.HandlerFunc(h.SynthesizeWS))
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	tests := []struct {
+		name   string
+		origin string
+		ok     bool
+	}{
+		{"sem Origin", "", true},
+		{"mesma origem", server.URL, true},
+		{"origem liberada", "https://APP.exemplo.com", true},
+		{"outra origem", "https://atacante.exemplo", false},
+		{"porta diferente", "http://" + strings.Split(strings.TrimPrefix(server.URL, "http://"), ":")[0] + ":1", false},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.origin != "" {
+			header.Set("Origin", tt.origin)
+		}
+		conn, resp, err := websocket.DefaultDialer.Dial(wsURL, header)
+		if conn != nil {
+			conn.Close()
+		}
+		if tt.ok && err != nil {
+			t.Errorf("%s: conexão recusada: %v", tt.name, err)
+		}
+		if !tt.ok && (err == nil || resp == nil || resp.StatusCode != http.StatusForbidden) {
+			t.Errorf("%s: conexão aceita ou status inesperado: %v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"tts-api/internal/audio"
+	"tts-api/internal/audio/wav"
+	"tts-api/internal/config"
+	"tts-api/internal/ssml"
+	"tts-api/internal/voice"
+)
+
+var (
+	// ErrQueueFull é retornado quando a fila de jobs atingiu o limite
+	ErrQueueFull = errors.New("fila de jobs cheia")
+	// ErrNotFound é retornado para jobs inexistentes ou já expirados
+	ErrNotFound = errors.New("job não encontrado")
+)
+
+// janitorInterval é a frequência da remoção de jobs expirados
+const janitorInterval = time.Minute
+
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Finished indica se o job não será mais processado
+func (s Status) Finished() bool {
+	return s == StatusCompleted || s == StatusFailed || s == StatusCancelled
+}
+
+// Spec descreve o que o job deve sintetizar
+type Spec struct {
+	Request       voice.Request
+	Segments      []ssml.Segment // frases ou segmentos SSML de Request.Text
+	Encoding      audio.Encoding
+	EncodeOptions audio.Options
+	CallbackURL   string
+}
+
+// Progress indica quantos segmentos (frases ou pausas) já foram sintetizados
+type Progress struct {
+	Completed int     `json:"completed"`
+	Total     int     `json:"total"`
+	Percent   float64 `json:"percent"`
+}
+
+// Job é o estado de uma síntese assíncrona. Os valores retornados pelo
+// Manager são cópias e podem ser lidos sem sincronização.
+type Job struct {
+	ID         string     `json:"id"`
+	Status     Status     `json:"status"`
+	Voice      string     `json:"voice"`
+	Encoding   string     `json:"encoding"`
+	Progress   Progress   `json:"progress"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Erro       string     `json:"erro,omitempty"`
+
+	// Preenchidos quando o job é concluído
+	AudioURL   string  `json:"audio_url,omitempty"`
+	Duration   float64 `json:"duration,omitempty"`
+	MimeType   string  `json:"mime_type,omitempty"`
+	Codec      string  `json:"codec,omitempty"`
+	SampleRate int     `json:"sample_rate,omitempty"`
+	Size       int     `json:"size,omitempty"`
+
+	spec   Spec
+	result *audio.Encoded
+	cancel context.CancelFunc
+}
+
+// Manager mantém a fila de jobs, os workers que os processam e os
+// resultados até que expirem
+type Manager struct {
+	voices    *voice.Manager
+	retention time.Duration
+	webhooks  *webhookSender
+
+	queue chan *Job
+	ctx   context.Context
+	stop  context.CancelFunc
+	wg    sync.WaitGroup
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewManager cria o gerenciador e inicia os workers e a limpeza periódica
+func NewManager(vm *voice.Manager, cfg *config.Config) *Manager {
+	workers := cfg.JobWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	queueSize := cfg.JobQueueSize
+	if queueSize < 1 {
+		queueSize = 1
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	m := &Manager{
+		voices:    vm,
+		retention: time.Duration(cfg.JobRetentionMinutes) * time.Minute,
+		webhooks:  newWebhookSender(cfg.WebhookSecret, cfg.WebhookAllowedHosts),
+		queue:     make(chan *Job, queueSize),
+		ctx:       ctx,
+		stop:      stop,
+		jobs:      make(map[string]*Job),
+	}
+
+	for i := 0; i < workers; i++ {
+		m.wg.Add(1)
+		go m.worker()
+	}
+	m.wg.Add(1)
+	go m.janitor()
+
+	return m
+}
+
+// CheckCallbackURL valida o callback_url de um novo job. Nomes que resolvem
+// para a rede interna só são recusados na entrega do webhook.
+func (m *Manager) CheckCallbackURL(raw string) error {
+	return m.webhooks.check(raw)
+}
+
+// Submit enfileira um novo job
+func (m *Manager) Submit(spec Spec) (Job, error) {
+	id, err := newID()
+	if err != nil {
+		return Job{}, err
+	}
+
+	job := &Job{
+		ID:        id,
+		Status:    StatusQueued,
+		Voice:     spec.Request.Voice,
+		Encoding:  string(spec.Encoding),
+		Progress:  Progress{Total: len(spec.Segments)},
+		CreatedAt: time.Now().UTC(),
+		spec:      spec,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	select {
+	case m.queue <- job:
+	default:
+		return Job{}, ErrQueueFull
+	}
+	m.jobs[id] = job
+	return job.snapshot(), nil
+}
+
+// Get retorna o estado atual do job
+func (m *Manager) Get(id string) (Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, exists := m.jobs[id]
+	if !exists {
+		return Job{}, false
+	}
+	return job.snapshot(), true
+}
+
+// Result retorna o job e o áudio gerado; o áudio é nil enquanto o job não
+// estiver concluído
+func (m *Manager) Result(id string) (Job, *audio.Encoded, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, exists := m.jobs[id]
+	if !exists {
+		return Job{}, nil, false
+	}
+	return job.snapshot(), job.result, true
+}
+
+// Cancel interrompe um job em andamento, que permanece consultável até
+// expirar. Jobs já finalizados são removidos; nesse caso removed é true.
+func (m *Manager) Cancel(id string) (job Job, removed bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, exists := m.jobs[id]
+	if !exists {
+		return Job{}, false, ErrNotFound
+	}
+	if j.Status.Finished() {
+		delete(m.jobs, id)
+		return j.snapshot(), true, nil
+	}
+
+	if j.cancel != nil {
+		j.cancel()
+	}
+	m.finishLocked(j, StatusCancelled)
+	return j.snapshot(), false, nil
+}
+
+// Close interrompe os jobs em andamento e encerra os workers
+func (m *Manager) Close() {
+	m.stop()
+	m.wg.Wait()
+}
+
+func (m *Manager) worker() {
+	defer m.wg.Done()
+	for {
+		select {
+		case job := <-m.queue:
+			m.run(job)
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *Manager) run(job *Job) {
+	ctx, cancel := context.WithCancel(m.ctx)
+	defer cancel()
+
+	m.mu.Lock()
+	if job.Status != StatusQueued {
+		// Cancelado enquanto aguardava na fila
+		m.mu.Unlock()
+		return
+	}
+	now := time.Now().UTC()
+	job.Status = StatusRunning
+	job.StartedAt = &now
+	job.cancel = cancel
+	m.mu.Unlock()
+
+	result, err := m.synthesize(ctx, job)
+
+	m.mu.Lock()
+	if job.Status == StatusCancelled {
+		m.mu.Unlock()
+		return
+	}
+	if err != nil {
+		job.Erro = err.Error()
+		m.finishLocked(job, StatusFailed)
+	} else {
+		job.result = result
+		job.AudioURL = fmt.Sprintf("/jobs/%s/audio", job.ID)
+		job.Duration = result.Duration
+		job.MimeType = result.MimeType
+		job.Codec = result.Codec
+		job.SampleRate = result.SampleRate
+		job.Size = len(result.Data)
+		m.finishLocked(job, StatusCompleted)
+	}
+	snapshot := job.snapshot()
+	m.mu.Unlock()
+
+	if err != nil {
+		log.Printf("Job %s falhou: %v", job.ID, err)
+	}
+	if job.spec.CallbackURL != "" {
+		m.webhooks.Send(m.ctx, job.spec.CallbackURL, snapshot)
+	}
+}
+
+// synthesize gera o áudio dos segmentos em paralelo, atualizando o progresso
+// conforme são concluídos em ordem, e codifica o resultado no formato
+// solicitado
+func (m *Manager) synthesize(ctx context.Context, job *Job) (*audio.Encoded, error) {
+	var combined *wav.PCM
+	total := len(job.spec.Segments)
+
+	err := ssml.RenderEach(ctx, m.voices, job.spec.Request, job.spec.Segments, func(i int, data []byte) error {
+		pcm, err := wav.Decode(data)
+		if err != nil {
+			return fmt.Errorf("trecho %d de %d: %w", i+1, total, err)
+		}
+
+		if combined == nil {
+			combined = pcm
+		} else {
+			if pcm.SampleRate != combined.SampleRate {
+				pcm = audio.Resample(pcm, combined.SampleRate)
+			}
+			combined.Samples = append(combined.Samples, pcm.Samples...)
+		}
+
+		m.mu.Lock()
+		job.Progress.Completed = i + 1
+		job.Progress.Percent = 100 * float64(i+1) / float64(job.Progress.Total)
+		m.mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if combined == nil {
+		return nil, errors.New("texto não pode estar vazio")
+	}
+
+	return audio.Encode(wav.Encode(combined), job.spec.Encoding, job.spec.EncodeOptions)
+}
+
+// finishLocked marca o fim do job e agenda sua expiração; requer m.mu
+func (m *Manager) finishLocked(job *Job, status Status) {
+	now := time.Now().UTC()
+	expires := now.Add(m.retention)
+	job.Status = status
+	job.FinishedAt = &now
+	job.ExpiresAt = &expires
+}
+
+// janitor remove periodicamente os jobs cujo resultado expirou
+func (m *Manager) janitor() {
+	defer m.wg.Done()
+	ticker := time.NewTicker(janitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			m.mu.Lock()
+			for id, job := range m.jobs {
+				if job.ExpiresAt != nil && now.After(*job.ExpiresAt) {
+					delete(m.jobs, id)
+				}
+			}
+			m.mu.Unlock()
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+func (j *Job) snapshot() Job {
+	s := *j
+	s.spec = Spec{}
+	s.result = nil
+	s.cancel = nil
+	return s
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("erro ao gerar id do job: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
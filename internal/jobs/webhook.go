@@ -0,0 +1,178 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	webhookMaxAttempts = 5
+	webhookTimeout     = 10 * time.Second
+	webhookBackoff     = 2 * time.Second // dobra a cada nova tentativa
+
+	// Cabeçalhos enviados com o webhook. A assinatura é o HMAC-SHA256, em
+	// hexadecimal, de "<timestamp>.<corpo>" usando WEBHOOK_SECRET.
+	HeaderEvent     = "X-GoTTS-Event"
+	HeaderTimestamp = "X-GoTTS-Timestamp"
+	HeaderSignature = "X-GoTTS-Signature"
+)
+
+// errInternalAddress é retornado ao conectar em um endereço interno que não
+// está em WEBHOOK_ALLOWED_HOSTS
+var errInternalAddress = errors.New("callback_url aponta para um endereço interno")
+
+// webhookSender entrega o estado final dos jobs no callback_url informado
+type webhookSender struct {
+	secret  []byte
+	allowed map[string]bool
+	client  *http.Client
+}
+
+func newWebhookSender(secret string, allowedHosts []string) *webhookSender {
+	s := &webhookSender{
+		secret:  []byte(secret),
+		allowed: make(map[string]bool),
+	}
+	for _, host := range allowedHosts {
+		s.allowed[strings.ToLower(host)] = true
+	}
+
+	// O endereço é verificado depois da resolução de nomes, ao conectar, o
+	// que também cobre redirecionamentos e nomes que apontam para a rede
+	// interna. Sem proxy, para que a verificação veja o destino real.
+	dialer := &net.Dialer{Timeout: webhookTimeout, Control: controlAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if host, _, err := net.SplitHostPort(addr); err == nil && s.allowed[strings.ToLower(host)] {
+			return (&net.Dialer{Timeout: webhookTimeout}).DialContext(ctx, network, addr)
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}
+	s.client = &http.Client{Timeout: webhookTimeout, Transport: transport}
+	return s
+}
+
+// check recusa callback_url que não seja http(s) ou cujo host seja um
+// endereço interno conhecido antes mesmo da resolução de nomes
+func (s *webhookSender) check(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("callback_url deve ser uma URL http ou https")
+	}
+	host := strings.ToLower(u.Hostname())
+	if s.allowed[host] {
+		return nil
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errInternalAddress
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && internalAddress(ip) {
+		return errInternalAddress
+	}
+	return nil
+}
+
+// controlAddress impede a conexão com endereços internos
+func controlAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if internalAddress(addrPort.Addr()) {
+		return errInternalAddress
+	}
+	return nil
+}
+
+// internalAddress indica loopback, redes privadas, link-local (como o
+// serviço de metadados 169.254.169.254), CGNAT e endereços não roteáveis
+func internalAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// sharedAddressSpace é a faixa de CGNAT (RFC 6598)
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Send entrega o webhook em segundo plano, repetindo com espera exponencial
+// enquanto o destino não responder com 2xx
+func (s *webhookSender) Send(ctx context.Context, url string, job Job) {
+	body, err := json.Marshal(job)
+	if err != nil {
+		log.Printf("Erro ao serializar webhook do job %s: %v", job.ID, err)
+		return
+	}
+	event := "job." + string(job.Status)
+
+	go func() {
+		backoff := webhookBackoff
+		for attempt := 1; ; attempt++ {
+			err := s.deliver(ctx, url, event, body)
+			if err == nil {
+				return
+			}
+			if attempt == webhookMaxAttempts {
+				log.Printf("Webhook do job %s não entregue após %d tentativas: %v", job.ID, attempt, err)
+				return
+			}
+
+			select {
+			case <-time.After(backoff):
+				backoff *= 2
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *webhookSender) deliver(ctx context.Context, url, event string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(s.secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("destino respondeu com status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign calcula a assinatura de um webhook, permitindo que o receptor valide
+// a origem e o horário do envio
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
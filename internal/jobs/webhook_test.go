@@ -0,0 +1,71 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestWebhookCheck(t *testing.T) {
+	s := newWebhookSender("segredo", []string{"interno.exemplo", "10.0.0.5"})
+
+	tests := []struct {
+		url string
+		ok  bool
+	}{
+		{"https://exemplo.com/hook", true},
+		{"http://203.0.113.10:8080/hook", true},
+		{"ftp://exemplo.com/hook", false},
+		{"https:///hook", false},
+		{"http://localhost/hook", false},
+		{"http://api.localhost/hook", false},
+		{"http://127.0.0.1/hook", false},
+		{"http://[::1]/hook", false},
+		{"http://[::ffff:127.0.0.1]/hook", false},
+		{"http://169.254.169.254/latest/meta-data/", false},
+		{"http://192.168.0.1/hook", false},
+		{"http://100.64.0.1/hook", false},
+		{"http://0.0.0.0/hook", false},
+		// Liberados em WEBHOOK_ALLOWED_HOSTS
+		{"http://10.0.0.5/hook", true},
+		{"http://Interno.Exemplo/hook", true},
+	}
+	for _, tt := range tests {
+		if err := s.check(tt.url); (err == nil) != tt.ok {
+			t.Errorf("check(%q) = %v, esperado ok=%v", tt.url, err, tt.ok)
+		}
+	}
+}
+
+func TestWebhookDeliverInternalAddress(t *testing.T) {
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+	}))
+	defer server.Close()
+
+	// O nome localhost só é resolvido ao conectar
+	u, _ := url.Parse(server.URL)
+	byName := "http://localhost:" + u.Port() + "/hook"
+
+	s := newWebhookSender("segredo", nil)
+	for _, target := range []string{server.URL, byName} {
+		if err := s.deliver(context.Background(), target, "job.completed", []byte("{}")); !errors.Is(err, errInternalAddress) {
+			t.Errorf("entrega para %s: %v, esperado endereço interno recusado", target, err)
+		}
+	}
+	if received != 0 {
+		t.Fatalf("%d webhooks recebidos por endereço interno", received)
+	}
+
+	s = newWebhookSender("segredo", []string{"localhost"})
+	if err := s.deliver(context.Background(), byName, "job.completed", []byte("{}")); err != nil {
+		t.Fatalf("entrega para host liberado: %v", err)
+	}
+	if received != 1 {
+		t.Fatalf("%d webhooks recebidos, esperado 1", received)
+	}
+}
@@ -0,0 +1,205 @@
+package lexicon
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format é um formato de importação e exportação do léxico
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatPLS  Format = "pls" // W3C Pronunciation Lexicon Specification 1.0
+)
+
+// MimeType retorna o Content-Type do formato
+func (f Format) MimeType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatPLS:
+		return "application/pls+xml"
+	default:
+		return "application/json"
+	}
+}
+
+// ParseFormat valida o nome do formato; vazio é JSON
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case "":
+		return FormatJSON, nil
+	case FormatJSON, FormatCSV, FormatPLS:
+		return f, nil
+	}
+	return "", fmt.Errorf("formato %q inválido; use json, csv ou pls", name)
+}
+
+// csvHeader é a primeira linha do CSV exportado; na importação é opcional
+var csvHeader = []string{"word", "alias", "phonemes"}
+
+// ReadCSV lê linhas "palavra,alias,fonemas"; apenas uma das duas últimas
+// colunas deve estar preenchida
+func ReadCSV(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var entries []Entry
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV inválido: %v", err)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), csvHeader[0]) {
+			continue
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("linha %d do CSV: esperadas as colunas word, alias e phonemes", line)
+		}
+
+		e := Entry{Word: record[0], Alias: strings.TrimSpace(record[1])}
+		if len(record) == 3 {
+			e.Phonemes = strings.TrimSpace(record[2])
+		}
+		if err := e.Validate(); err != nil {
+			return nil, fmt.Errorf("linha %d do CSV: %v", line, err)
+		}
+		entries = append(entries, e)
+	}
+}
+
+// WriteCSV escreve as entradas com cabeçalho
+func WriteCSV(w io.Writer, entries []Entry) error {
+	writer := csv.NewWriter(w)
+	writer.Write(csvHeader)
+	for _, e := range entries {
+		writer.Write([]string{e.Word, e.Alias, e.Phonemes})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+const plsNamespace = "http://www.w3.org/2005/01/pronunciation-lexicon"
+
+type plsDocument struct {
+	XMLName  xml.Name    `xml:"lexicon"`
+	Version  string      `xml:"version,attr"`
+	Xmlns    string      `xml:"xmlns,attr,omitempty"`
+	Alphabet string      `xml:"alphabet,attr"`
+	Lang     string      `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Lexemes  []plsLexeme `xml:"lexeme"`
+}
+
+type plsLexeme struct {
+	Graphemes []string     `xml:"grapheme"`
+	Phonemes  []plsPhoneme `xml:"phoneme"`
+	Aliases   []string     `xml:"alias"`
+}
+
+type plsPhoneme struct {
+	Alphabet string `xml:"alphabet,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+// ReadPLS lê um léxico PLS. Cada grafema do lexema gera uma entrada com a
+// primeira pronúncia (fonema IPA ou alias) declarada.
+func ReadPLS(r io.Reader) ([]Entry, error) {
+	var doc plsDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("PLS inválido: %v", err)
+	}
+	if doc.XMLName.Space != "" && doc.XMLName.Space != plsNamespace {
+		return nil, fmt.Errorf("PLS inválido: namespace %q desconhecido", doc.XMLName.Space)
+	}
+
+	var entries []Entry
+	for i, lexeme := range doc.Lexemes {
+		if len(lexeme.Graphemes) == 0 {
+			return nil, fmt.Errorf("lexema %d do PLS sem <grapheme>", i+1)
+		}
+
+		var pronunciation Entry
+		switch {
+		case len(lexeme.Phonemes) > 0:
+			phoneme := lexeme.Phonemes[0]
+			alphabet := phoneme.Alphabet
+			if alphabet == "" {
+				alphabet = doc.Alphabet
+			}
+			if !strings.EqualFold(alphabet, "ipa") {
+				return nil, fmt.Errorf("lexema %d do PLS: alfabeto %q não suportado, use ipa", i+1, alphabet)
+			}
+			pronunciation.Phonemes = strings.TrimSpace(phoneme.Value)
+		case len(lexeme.Aliases) > 0:
+			pronunciation.Alias = strings.TrimSpace(lexeme.Aliases[0])
+		}
+
+		for _, grapheme := range lexeme.Graphemes {
+			e := pronunciation
+			e.Word = strings.TrimSpace(grapheme)
+			if err := e.Validate(); err != nil {
+				return nil, fmt.Errorf("lexema %d do PLS: %v", i+1, err)
+			}
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// WritePLS escreve as entradas como um léxico PLS em IPA. language é o
+// código do idioma (pt_BR), convertido para a forma BCP 47 (pt-BR).
+func WritePLS(w io.Writer, entries []Entry, language string) error {
+	doc := plsDocument{
+		Version:  "1.0",
+		Xmlns:    plsNamespace,
+		Alphabet: "ipa",
+		Lang:     strings.ReplaceAll(language, "_", "-"),
+	}
+	for _, e := range entries {
+		lexeme := plsLexeme{Graphemes: []string{e.Word}}
+		if e.Phonemes != "" {
+			lexeme.Phonemes = []plsPhoneme{{Value: e.Phonemes}}
+		} else {
+			lexeme.Aliases = []string{e.Alias}
+		}
+		doc.Lexemes = append(doc.Lexemes, lexeme)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Read lê as entradas no formato informado
+func Read(r io.Reader, format Format) ([]Entry, error) {
+	switch format {
+	case FormatCSV:
+		return ReadCSV(r)
+	case FormatPLS:
+		return ReadPLS(r)
+	case FormatJSON:
+		return readJSON(r)
+	}
+	return nil, errors.New("formato de léxico desconhecido")
+}
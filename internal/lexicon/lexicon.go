@@ -0,0 +1,225 @@
+package lexicon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Entry é a pronúncia de uma palavra: uma grafia alternativa (alias) ou
+// uma sequência de fonemas IPA
+type Entry struct {
+	Word     string `json:"word"`
+	Alias    string `json:"alias,omitempty"`    // Grafia lida no lugar da palavra
+	Phonemes string `json:"phonemes,omitempty"` // Fonemas IPA, enviados ao engine entre [[ ]]
+}
+
+// Validate exige a palavra e exatamente uma das formas de pronúncia
+func (e Entry) Validate() error {
+	switch {
+	case strings.TrimSpace(e.Word) == "":
+		return errors.New("a palavra não pode estar vazia")
+	case e.Alias == "" && e.Phonemes == "":
+		return errors.New("informe alias ou phonemes")
+	case e.Alias != "" && e.Phonemes != "":
+		return errors.New("informe apenas alias ou phonemes")
+	}
+	return nil
+}
+
+// spoken retorna o texto que substitui a palavra
+func (e Entry) spoken() string {
+	if e.Phonemes != "" {
+		return "[[ " + e.Phonemes + " ]]"
+	}
+	return e.Alias
+}
+
+// Lexicon é um dicionário de pronúncias aplicado a palavras inteiras, sem
+// diferenciar maiúsculas
+type Lexicon struct {
+	mu      sync.RWMutex
+	entries map[string]Entry // chave: palavra em minúsculas
+	re      *regexp.Regexp   // nil quando vazio
+	version string           // resumo das entradas; vazio quando vazio
+}
+
+// New cria um léxico com as entradas informadas
+func New(entries []Entry) (*Lexicon, error) {
+	l := &Lexicon{entries: make(map[string]Entry)}
+	for _, e := range entries {
+		if err := e.Validate(); err != nil {
+			return nil, err
+		}
+		e.Word = strings.TrimSpace(e.Word)
+		l.entries[key(e.Word)] = e
+	}
+	l.compile()
+	return l, nil
+}
+
+// Entries retorna as entradas em ordem alfabética
+func (l *Lexicon) Entries() []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := make([]Entry, 0, len(l.entries))
+	for _, e := range l.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return key(entries[i].Word) < key(entries[j].Word) })
+	return entries
+}
+
+// Get retorna a entrada da palavra
+func (l *Lexicon) Get(word string) (Entry, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	e, ok := l.entries[key(word)]
+	return e, ok
+}
+
+// Len retorna a quantidade de entradas
+func (l *Lexicon) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.entries)
+}
+
+// Set inclui ou substitui entradas; created indica quantas eram novas
+func (l *Lexicon) Set(entries ...Entry) (created int, err error) {
+	for _, e := range entries {
+		if err := e.Validate(); err != nil {
+			return 0, err
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range entries {
+		e.Word = strings.TrimSpace(e.Word)
+		if _, exists := l.entries[key(e.Word)]; !exists {
+			created++
+		}
+		l.entries[key(e.Word)] = e
+	}
+	l.compile()
+	return created, nil
+}
+
+// Delete remove a entrada da palavra
+func (l *Lexicon) Delete(word string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, exists := l.entries[key(word)]; !exists {
+		return false
+	}
+	delete(l.entries, key(word))
+	l.compile()
+	return true
+}
+
+// Version identifica o conteúdo do léxico e muda a cada alteração das
+// entradas. É vazia para um léxico sem entradas.
+func (l *Lexicon) Version() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.version
+}
+
+// Apply substitui as palavras do léxico no texto
+func (l *Lexicon) Apply(text string) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.re == nil {
+		return text
+	}
+
+	// A busca recomeça no fim de cada palavra, e não no fim do delimitador
+	// consumido, para que palavras separadas por um único espaço casem
+	var b strings.Builder
+	last := 0
+	for pos := 0; pos < len(text); {
+		loc := l.re.FindStringSubmatchIndex(text[pos:])
+		if loc == nil {
+			break
+		}
+		start, end := pos+loc[2], pos+loc[3]
+		if start == pos && pos > 0 {
+			// ^ casou no início do recorte, que pode estar colado à palavra
+			// anterior
+			if before, _ := utf8.DecodeLastRuneInString(text[:pos]); isWordRune(before) {
+				_, size := utf8.DecodeRuneInString(text[pos:])
+				pos += size
+				continue
+			}
+		}
+		pos = end
+
+		e, ok := l.entries[key(text[start:end])]
+		if !ok {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(e.spoken())
+		last = end
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// compile monta a expressão com todas as palavras e recalcula a versão;
+// requer l.mu
+func (l *Lexicon) compile() {
+	if len(l.entries) == 0 {
+		l.re = nil
+		l.version = ""
+		return
+	}
+
+	keys := make([]string, 0, len(l.entries))
+	for k := range l.entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		e := l.entries[k]
+		fmt.Fprintf(h, "%s\x00%s\x00%s\n", e.Word, e.Alias, e.Phonemes)
+	}
+	l.version = hex.EncodeToString(h.Sum(nil))[:16]
+
+	words := make([]string, 0, len(l.entries))
+	for _, e := range l.entries {
+		words = append(words, regexp.QuoteMeta(e.Word))
+	}
+	// As mais longas primeiro, para que "Mercado Pago" vença "Mercado". Os
+	// limites de palavra fazem parte da expressão, de modo que uma
+	// alternativa longa colada a outras letras ("Mercado Pagodão") ainda
+	// deixa casar a mais curta.
+	sort.Slice(words, func(i, j int) bool {
+		if len(words[i]) != len(words[j]) {
+			return len(words[i]) > len(words[j])
+		}
+		return words[i] < words[j]
+	})
+	l.re = regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}_])(` + strings.Join(words, "|") + `)(?:[^\p{L}\p{N}_]|$)`)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func key(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
@@ -0,0 +1,120 @@
+package lexicon
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"tts-api/internal/config"
+)
+
+func TestApply(t *testing.T) {
+	l, err := New([]Entry{
+		{Word: "Mercado Pago", Alias: "mercado pagô"},
+		{Word: "Mercado", Alias: "mercadu"},
+		{Word: "SUS", Alias: "sus"},
+		{Word: "Itaú", Phonemes: "itaˈu"},
+		{Word: "C++", Alias: "cê mais mais"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in, want string
+	}{
+		{"Pague com Mercado Pago.", "Pague com mercado pagô."},
+		// A alternativa longa não casa e a curta ainda vale
+		{"Mercado Pagodão", "mercadu Pagodão"},
+		{"o mercado", "o mercadu"},
+		{"SUS SUS,SUS", "sus sus,sus"},
+		{"SUSPENSO e sus", "SUSPENSO e sus"},
+		{"ITAÚ", "[[ itaˈu ]]"},
+		{"Itaúna", "Itaúna"},
+		{"sei C++ e C", "sei cê mais mais e C"},
+		{"nada aqui", "nada aqui"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := l.Apply(tt.in); got != tt.want {
+			t.Errorf("Apply(%q) = %q, esperado %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestStorePersistence(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{VoicesDir: dir}
+
+	s := NewStore(cfg)
+	created, err := s.Set("faber", Entry{Word: "SUS", Alias: "sus"}, Entry{Word: "Itaú", Phonemes: "itaˈu"})
+	if err != nil || created != 2 {
+		t.Fatalf("Set: created=%d, err=%v", created, err)
+	}
+	if created, err := s.Set("faber", Entry{Word: "sus", Alias: "súss"}); err != nil || created != 0 {
+		t.Fatalf("Set de palavra existente: created=%d, err=%v", created, err)
+	}
+	if ok, err := s.Delete("faber", "itaú"); !ok || err != nil {
+		t.Fatalf("Delete: ok=%v, err=%v", ok, err)
+	}
+	if ok, _ := s.Delete("faber", "inexistente"); ok {
+		t.Fatal("Delete de palavra ausente retornou true")
+	}
+	if _, err := s.Set("faber", Entry{Word: "vazio"}); err == nil {
+		t.Fatal("Set aceitou entrada sem pronúncia")
+	}
+
+	// Um novo Store lê o que foi gravado
+	l, err := NewStore(cfg).Lexicon("faber")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{{Word: "sus", Alias: "súss"}}
+	if got := l.Entries(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("entradas gravadas %+v, esperado %+v", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "faber", fileName)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStoreInitialFile(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "faber"), 0755)
+	os.WriteFile(filepath.Join(dir, "faber", "lexicon.csv"), []byte("word,alias,phonemes\nSUS,sus,\n"), 0644)
+
+	s := NewStore(&config.Config{VoicesDir: dir})
+	if got := s.Apply("faber", "o SUS"); got != "o sus" {
+		t.Fatalf("léxico inicial em CSV não aplicado: %q", got)
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	entries := []Entry{
+		{Word: "Itaú", Phonemes: "itaˈu"},
+		{Word: "Mercado Pago", Alias: "mercado pagô"},
+		{Word: "R&D, \"P\"", Alias: "pesquisa <e> desenvolvimento"},
+	}
+
+	for _, format := range []Format{FormatCSV, FormatPLS} {
+		var buf bytes.Buffer
+		var err error
+		if format == FormatCSV {
+			err = WriteCSV(&buf, entries)
+		} else {
+			err = WritePLS(&buf, entries, "pt_BR")
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+
+		got, err := Read(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if !reflect.DeepEqual(got, entries) {
+			t.Errorf("%s: lido %+v, esperado %+v", format, got, entries)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package lexicon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"tts-api/internal/config"
+)
+
+// fileName é o arquivo em que o léxico da voz é persistido
+const fileName = "lexicon.json"
+
+// lexiconFiles são lidos em ordem; lexicon.pls e lexicon.csv servem de
+// conteúdo inicial enquanto lexicon.json não existe
+var lexiconFiles = []struct {
+	name   string
+	format Format
+}{{fileName, FormatJSON}, {"lexicon.pls", FormatPLS}, {"lexicon.csv", FormatCSV}}
+
+// Store mantém os léxicos das vozes, carregados do disco no primeiro uso
+type Store struct {
+	dir func(voice string) string
+
+	mu       sync.Mutex
+	lexicons map[string]*Lexicon
+}
+
+// NewStore usa o diretório de cada voz ou, se configurado, um
+// subdiretório por voz em cfg.LexiconDir
+func NewStore(cfg *config.Config) *Store {
+	base := cfg.VoicesDir
+	if cfg.LexiconDir != "" {
+		base = cfg.LexiconDir
+	}
+	return &Store{
+		dir:      func(voice string) string { return filepath.Join(base, voice) },
+		lexicons: make(map[string]*Lexicon),
+	}
+}
+
+// Lexicon retorna o léxico da voz, carregando-o se necessário
+func (s *Store) Lexicon(voice string) (*Lexicon, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lexiconLocked(voice)
+}
+
+// Apply aplica o léxico da voz ao texto. Falhas ao carregar o léxico são
+// registradas e o texto segue inalterado.
+func (s *Store) Apply(voice, text string) string {
+	l, err := s.Lexicon(voice)
+	if err != nil {
+		log.Printf("Aviso: léxico da voz %s ignorado: %v", voice, err)
+		return text
+	}
+	return l.Apply(text)
+}
+
+// Version retorna a versão do léxico da voz, vazia se não houver entradas
+// ou se o léxico não puder ser carregado
+func (s *Store) Version(voice string) string {
+	l, err := s.Lexicon(voice)
+	if err != nil {
+		return ""
+	}
+	return l.Version()
+}
+
+// Set inclui ou substitui entradas e grava o léxico. As alterações só
+// passam a valer depois de gravadas.
+func (s *Store) Set(voice string, entries ...Entry) (created int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, err := s.lexiconLocked(voice)
+	if err != nil {
+		return 0, err
+	}
+	next, _ := New(l.Entries())
+	if created, err = next.Set(entries...); err != nil {
+		return 0, err
+	}
+	if err := s.saveLocked(voice, next); err != nil {
+		return 0, err
+	}
+	s.lexicons[voice] = next
+	return created, nil
+}
+
+// Replace substitui todo o léxico da voz e o grava
+func (s *Store) Replace(voice string, entries []Entry) error {
+	l, err := New(entries)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.saveLocked(voice, l); err != nil {
+		return err
+	}
+	s.lexicons[voice] = l
+	return nil
+}
+
+// Delete remove a entrada e grava o léxico
+func (s *Store) Delete(voice, word string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, err := s.lexiconLocked(voice)
+	if err != nil {
+		return false, err
+	}
+	next, _ := New(l.Entries())
+	if !next.Delete(word) {
+		return false, nil
+	}
+	if err := s.saveLocked(voice, next); err != nil {
+		return false, err
+	}
+	s.lexicons[voice] = next
+	return true, nil
+}
+
+func (s *Store) lexiconLocked(voice string) (*Lexicon, error) {
+	if l, ok := s.lexicons[voice]; ok {
+		return l, nil
+	}
+	l, err := s.load(voice)
+	if err != nil {
+		return nil, err
+	}
+	s.lexicons[voice] = l
+	return l, nil
+}
+
+// load lê lexicon.json ou, na falta dele, um dos arquivos iniciais
+func (s *Store) load(voice string) (*Lexicon, error) {
+	dir := s.dir(voice)
+	for _, file := range lexiconFiles {
+		f, err := os.Open(filepath.Join(dir, file.name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries, err := Read(f, file.format)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file.name, err)
+		}
+		return New(entries)
+	}
+	return New(nil)
+}
+
+// saveLocked grava o léxico em lexicon.json via arquivo temporário, para
+// que uma falha não deixe o arquivo pela metade
+func (s *Store) saveLocked(voice string, l *Lexicon) error {
+	dir := s.dir(voice)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("erro ao criar o diretório do léxico: %v", err)
+	}
+
+	data, err := json.MarshalIndent(l.Entries(), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, fileName+".*")
+	if err != nil {
+		return fmt.Errorf("erro ao gravar o léxico: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("erro ao gravar o léxico: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("erro ao gravar o léxico: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, fileName)); err != nil {
+		return fmt.Errorf("erro ao gravar o léxico: %v", err)
+	}
+	return nil
+}
+
+// readJSON aceita a lista de entradas ou um objeto com o campo "entries",
+// como o exportado pela API
+func readJSON(r io.Reader) ([]Entry, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("JSON inválido: %v", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(raw, &entries); err == nil {
+		return entries, nil
+	}
+	var doc struct {
+		Entries []Entry `json:"entries"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("JSON inválido: %v", err)
+	}
+	return doc.Entries, nil
+}
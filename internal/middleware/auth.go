@@ -2,10 +2,17 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
+	"time"
 	"tts-api/internal/handlers"
+	"tts-api/internal/signedurl"
+
+	"github.com/gorilla/websocket"
 )
 
-func AuthMiddleware(token string) func(http.Handler) http.Handler {
+// AuthMiddleware exige o token nas rotas privadas. GET /synthesize também
+// aceita uma URL assinada com signedURLSecret.
+func AuthMiddleware(token, signedURLSecret string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Rotas públicas que não exigem autenticação
@@ -21,8 +28,21 @@ func AuthMiddleware(token string) func(http.Handler) http.Handler {
 				}
 			}
 
-			authToken := r.Header.Get("Authorization")
-			if authToken != "Bearer "+token {
+			if signedSynthesis(r) {
+				if err := signedurl.Verify(signedURLSecret, r.URL.Path, r.URL.Query(), time.Now()); err != nil {
+					handlers.WriteJSONError(w, http.StatusForbidden, err.Error())
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !authorized(r, token) {
+				// Clientes da API compatível com a OpenAI esperam o erro nesse formato
+				if strings.HasPrefix(r.URL.Path, "/v1/") {
+					handlers.WriteOpenAIError(w, http.StatusUnauthorized, "invalid_request_error", "", "Não autorizado")
+					return
+				}
 				handlers.WriteJSONError(w, http.StatusUnauthorized, "Não autorizado")
 				return
 			}
@@ -30,3 +50,31 @@ func AuthMiddleware(token string) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// signedSynthesis indica uma requisição a GET /synthesize com assinatura
+func signedSynthesis(r *http.Request) bool {
+	return (r.Method == http.MethodGet || r.Method == http.MethodHead) &&
+		r.URL.Path == "/synthesize" && r.URL.Query().Has(signedurl.SignatureParam)
+}
+
+// authorized verifica o token no cabeçalho Authorization. Em conexões
+// WebSocket, que não permitem cabeçalhos nos navegadores, o token também é
+// aceito no parâmetro token ou no subprotocolo "bearer.<token>".
+func authorized(r *http.Request, token string) bool {
+	if r.Header.Get("Authorization") == "Bearer "+token {
+		return true
+	}
+	if !websocket.IsWebSocketUpgrade(r) {
+		return false
+	}
+
+	if r.URL.Query().Get("token") == token {
+		return true
+	}
+	for _, protocol := range websocket.Subprotocols(r) {
+		if strings.TrimPrefix(protocol, "bearer.") == token && strings.HasPrefix(protocol, "bearer.") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,324 @@
+package normalize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EnglishUS normaliza textos em inglês americano
+type EnglishUS struct{}
+
+var enDigits = [10]string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+var enUnits = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+	"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+
+var enTens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+
+var enScales = []string{"", "thousand", "million", "billion", "trillion"}
+
+// enOrdinals são as terminações irregulares dos ordinais
+var enOrdinals = map[string]string{
+	"one": "first", "two": "second", "three": "third", "five": "fifth",
+	"eight": "eighth", "nine": "ninth", "twelve": "twelfth",
+}
+
+var enMonths = []string{"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+// enCurrencies mapeia o símbolo para os nomes da moeda e da fração
+var enCurrencies = map[string][4]string{
+	"$":   {"dollar", "dollars", "cent", "cents"},
+	"US$": {"dollar", "dollars", "cent", "cents"},
+	"€":   {"euro", "euros", "cent", "cents"},
+	"£":   {"pound", "pounds", "penny", "pence"},
+}
+
+var enAbbreviations = map[string]string{
+	"mr": "mister", "mrs": "missus", "ms": "miz", "dr": "doctor",
+	"prof": "professor", "jr": "junior", "sr": "senior", "vs": "versus",
+	"etc": "et cetera", "approx": "approximately", "dept": "department",
+	"e.g": "for example", "i.e": "that is",
+}
+
+// O número em inglês usa vírgula como separador de milhar e ponto como
+// separador decimal: 1,234.56
+const enNumber = `\d{1,3}(?:,\d{3})+(?:\.\d+)?|\d+(?:\.\d+)?`
+
+var enRules = []rule{
+	abbreviationRule(enAbbreviations),
+	{
+		re: regexp.MustCompile(`(?i)\bno\.\s?(\d)`),
+		replace: func(m []string) (string, bool) {
+			return "number " + m[1], true
+		},
+	},
+	// Telefones: (555) 123-4567, 555-123-4567, +1 555 123 4567
+	{
+		re: regexp.MustCompile(`(?:\+1[\s.-]?)?(?:\(\d{3}\)\s?|\b\d{3}[\s.-])\d{3}[\s.-]\d{4}\b`),
+		replace: func(m []string) (string, bool) {
+			return spellDigits(m[0], enDigits, "plus"), true
+		},
+	},
+	// Valores monetários: $1,234.56, €3.50, $2 million
+	{
+		re:      regexp.MustCompile(`(US\$|\$|€|£)\s?(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d{1,2}))?(?:\s(thousand|million|billion|trillion)\b)?`),
+		replace: enCurrency,
+	},
+	// Datas: 03/12/2025 (mês/dia/ano) e 2025-03-12
+	{
+		re: regexp.MustCompile(`\b(\d{1,2})/(\d{1,2})/(\d{4})\b`),
+		replace: func(m []string) (string, bool) {
+			return enDate(m[1], m[2], m[3])
+		},
+	},
+	{
+		re: regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`),
+		replace: func(m []string) (string, bool) {
+			return enDate(m[2], m[3], m[1])
+		},
+	},
+	// Horários: 2:30, 2:30 pm, 2pm, 2 p.m.
+	{
+		re: regexp.MustCompile(`(?i)\b(\d{1,2}):(\d{2})(?:\s?([ap])(?:m\b|\.m\.))?`),
+		replace: func(m []string) (string, bool) {
+			return enTime(m[1], m[2], m[3])
+		},
+	},
+	{
+		re: regexp.MustCompile(`(?i)\b(\d{1,2})\s?([ap])(?:m\b|\.m\.)`),
+		replace: func(m []string) (string, bool) {
+			return enTime(m[1], "", m[2])
+		},
+	},
+	// Ordinais: 1st, 2nd, 3rd, 4th
+	{
+		re: regexp.MustCompile(`(?i)\b(\d+)(st|nd|rd|th)\b`),
+		replace: func(m []string) (string, bool) {
+			if len(m[1]) > maxDigits {
+				return "", false
+			}
+			n, _ := strconv.ParseInt(m[1], 10, 64)
+			return enOrdinal(n), true
+		},
+	},
+	// Porcentagens: 50%, 12.5 %
+	{
+		re: regexp.MustCompile(`(` + enNumber + `)\s?%`),
+		replace: func(m []string) (string, bool) {
+			return enReadNumber(m[1]) + " percent", true
+		},
+	},
+	// Intervalos (1999-2000) e números negativos
+	{
+		re: regexp.MustCompile(`(\d)\s?-\s?(\d)`),
+		replace: func(m []string) (string, bool) {
+			return m[1] + " to " + m[2], true
+		},
+	},
+	{
+		re: regexp.MustCompile(`(^|[\s(])-(\d)`),
+		replace: func(m []string) (string, bool) {
+			return m[1] + "minus " + m[2], true
+		},
+	},
+	// Anos: 1999, 2025
+	{
+		re: regexp.MustCompile(`\b(?:1[1-9]|20)\d{2}\b`),
+		replace: func(m []string) (string, bool) {
+			year, _ := strconv.Atoi(m[0])
+			return enYear(year), true
+		},
+	},
+	{
+		re: regexp.MustCompile(enNumber),
+		replace: func(m []string) (string, bool) {
+			return enReadNumber(m[0]), true
+		},
+	},
+}
+
+// Normalize escreve por extenso números, valores, datas, horários,
+// ordinais, porcentagens, telefones e abreviações comuns
+func (EnglishUS) Normalize(text string) string {
+	return applyRules(text, enRules)
+}
+
+func enCardinal(n int64) string {
+	if n == 0 {
+		return "zero"
+	}
+	if n < 0 {
+		return "minus " + enCardinal(-n)
+	}
+
+	var groups []int
+	for ; n > 0; n /= 1000 {
+		groups = append(groups, int(n%1000))
+	}
+
+	var parts []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		if g := groups[i]; g > 0 {
+			words := enBelowThousand(g)
+			if i > 0 {
+				words += " " + enScales[i]
+			}
+			parts = append(parts, words)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func enBelowThousand(n int) string {
+	var parts []string
+	if h := n / 100; h > 0 {
+		parts = append(parts, enUnits[h]+" hundred")
+	}
+	switch rest := n % 100; {
+	case rest == 0:
+	case rest < 20:
+		parts = append(parts, enUnits[rest])
+	case rest%10 == 0:
+		parts = append(parts, enTens[rest/10])
+	default:
+		parts = append(parts, enTens[rest/10]+"-"+enUnits[rest%10])
+	}
+	return strings.Join(parts, " ")
+}
+
+// enOrdinal troca a última palavra do cardinal pela forma ordinal
+func enOrdinal(n int64) string {
+	cardinal := enCardinal(n)
+	cut := strings.LastIndexAny(cardinal, " -") + 1
+	prefix, last := cardinal[:cut], cardinal[cut:]
+
+	switch {
+	case enOrdinals[last] != "":
+		last = enOrdinals[last]
+	case strings.HasSuffix(last, "y"):
+		last = strings.TrimSuffix(last, "y") + "ieth"
+	default:
+		last += "th"
+	}
+	return prefix + last
+}
+
+// enYear lê o ano em pares de dígitos: 1999 é "nineteen ninety-nine",
+// 2025 é "twenty twenty-five" e 2005 é "two thousand five"
+func enYear(year int) string {
+	if year < 1000 || year > 9999 || year%1000 < 10 {
+		return enCardinal(int64(year))
+	}
+	high, low := year/100, year%100
+	switch {
+	case low == 0:
+		return enCardinal(int64(high)) + " hundred"
+	case low < 10:
+		return enCardinal(int64(high)) + " oh " + enUnits[low]
+	default:
+		return enCardinal(int64(high)) + " " + enCardinal(int64(low))
+	}
+}
+
+// enReadNumber lê um número no formato americano (1,234.56); a parte
+// decimal é lida dígito a dígito
+func enReadNumber(s string) string {
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+	integer = strings.ReplaceAll(integer, ",", "")
+
+	var spoken string
+	if readAsDigits(integer) {
+		spoken = spellDigits(integer, enDigits, "plus")
+	} else {
+		n, _ := strconv.ParseInt(integer, 10, 64)
+		spoken = enCardinal(n)
+	}
+	if hasFraction {
+		spoken += " point " + spellDigits(fraction, enDigits, "plus")
+	}
+	return spoken
+}
+
+func enCurrency(m []string) (string, bool) {
+	names := enCurrencies[m[1]]
+	integer := strings.ReplaceAll(m[2], ",", "")
+	if len(integer) > maxDigits {
+		return "", false
+	}
+	n, _ := strconv.ParseInt(integer, 10, 64)
+
+	// $2 million, $1.5 billion
+	if scale := m[4]; scale != "" {
+		spoken := enCardinal(n)
+		if m[3] != "" {
+			spoken += " point " + spellDigits(m[3], enDigits, "plus")
+		}
+		return spoken + " " + scale + " " + names[1], true
+	}
+
+	cents := 0
+	if m[3] != "" {
+		cents, _ = strconv.Atoi(m[3])
+		if len(m[3]) == 1 {
+			cents *= 10
+		}
+	}
+
+	var parts []string
+	if n > 0 || cents == 0 {
+		name := names[1]
+		if n == 1 {
+			name = names[0]
+		}
+		parts = append(parts, enCardinal(n)+" "+name)
+	}
+	if cents > 0 {
+		name := names[3]
+		if cents == 1 {
+			name = names[2]
+		}
+		parts = append(parts, enCardinal(int64(cents))+" "+name)
+	}
+	return strings.Join(parts, " and "), true
+}
+
+func enDate(m, d, y string) (string, bool) {
+	month, _ := strconv.Atoi(m)
+	day, _ := strconv.Atoi(d)
+	year, _ := strconv.Atoi(y)
+	if day < 1 || day > 31 || month < 1 || month > 12 {
+		return "", false
+	}
+	return enMonths[month-1] + " " + enOrdinal(int64(day)) + ", " + enYear(year), true
+}
+
+// enTime lê o horário; period é "a" ou "p" quando informado am/pm
+func enTime(hour, minute, period string) (string, bool) {
+	h, _ := strconv.Atoi(hour)
+	if h > 23 || (period != "" && (h < 1 || h > 12)) {
+		return "", false
+	}
+	spoken := enCardinal(int64(h))
+
+	if minute != "" {
+		m, _ := strconv.Atoi(minute)
+		switch {
+		case m > 59:
+			return "", false
+		case m == 0 && period == "":
+			spoken += " o'clock"
+		case m == 0:
+		case m < 10:
+			spoken += " oh " + enUnits[m]
+		default:
+			spoken += " " + enCardinal(int64(m))
+		}
+	}
+
+	if period != "" {
+		spoken += " " + strings.ToLower(period) + " m"
+	}
+	return spoken, true
+}
@@ -0,0 +1,90 @@
+package normalize
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Normalizer reescreve números, datas, moedas e abreviações por extenso,
+// na forma em que devem ser lidos pela voz
+type Normalizer interface {
+	Normalize(text string) string
+}
+
+var (
+	normalizersMu sync.RWMutex
+	normalizers   = make(map[string]Normalizer)
+)
+
+func init() {
+	Register("pt_BR", PortugueseBR{})
+	Register("en_US", EnglishUS{})
+}
+
+// Register registra o normalizador de um idioma (pt_BR, en_US)
+func Register(language string, n Normalizer) {
+	normalizersMu.Lock()
+	defer normalizersMu.Unlock()
+	normalizers[canonical(language)] = n
+}
+
+// Languages retorna os idiomas com normalizador registrado
+func Languages() []string {
+	normalizersMu.RLock()
+	defer normalizersMu.RUnlock()
+	return languagesLocked()
+}
+
+// For retorna o normalizador do idioma. Sem correspondência exata, usa o
+// de mesmo idioma base (pt_PT usa o de pt_BR).
+func For(language string) (Normalizer, bool) {
+	language = canonical(language)
+	if language == "" {
+		return nil, false
+	}
+
+	normalizersMu.RLock()
+	defer normalizersMu.RUnlock()
+	if n, ok := normalizers[language]; ok {
+		return n, true
+	}
+
+	base, _, _ := strings.Cut(language, "_")
+	for _, candidate := range languagesLocked() {
+		if b, _, _ := strings.Cut(candidate, "_"); b == base {
+			return normalizers[candidate], true
+		}
+	}
+	return nil, false
+}
+
+// Text normaliza o texto com o normalizador do idioma; idiomas sem
+// normalizador retornam o texto inalterado
+func Text(language, text string) string {
+	n, ok := For(language)
+	if !ok {
+		return text
+	}
+	return n.Normalize(text)
+}
+
+// languagesLocked retorna os idiomas em ordem; requer normalizersMu
+func languagesLocked() []string {
+	languages := make([]string, 0, len(normalizers))
+	for language := range normalizers {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// canonical converte "pt-br" e "pt_BR" para "pt_BR"
+func canonical(language string) string {
+	language = strings.ReplaceAll(strings.TrimSpace(language), "-", "_")
+	base, region, found := strings.Cut(language, "_")
+	if !found {
+		return strings.ToLower(base)
+	}
+	return strings.ToLower(base) + "_" + strings.ToUpper(region)
+}
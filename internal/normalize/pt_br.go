@@ -0,0 +1,447 @@
+package normalize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PortugueseBR normaliza textos em português do Brasil
+type PortugueseBR struct{}
+
+var ptDigits = [10]string{"zero", "um", "dois", "três", "quatro", "cinco", "seis", "sete", "oito", "nove"}
+
+var ptUnits = []string{"zero", "um", "dois", "três", "quatro", "cinco", "seis", "sete", "oito", "nove",
+	"dez", "onze", "doze", "treze", "catorze", "quinze", "dezesseis", "dezessete", "dezoito", "dezenove"}
+
+var ptTens = []string{"", "", "vinte", "trinta", "quarenta", "cinquenta", "sessenta", "setenta", "oitenta", "noventa"}
+
+var ptHundreds = []string{"", "cento", "duzentos", "trezentos", "quatrocentos", "quinhentos",
+	"seiscentos", "setecentos", "oitocentos", "novecentos"}
+
+// ptScales são os nomes das potências de mil a partir do milhão, no
+// singular e no plural
+var ptScales = [][2]string{{"milhão", "milhões"}, {"bilhão", "bilhões"}, {"trilhão", "trilhões"}}
+
+var ptOrdinalUnits = []string{"", "primeiro", "segundo", "terceiro", "quarto", "quinto", "sexto", "sétimo", "oitavo", "nono"}
+
+var ptOrdinalTens = []string{"", "décimo", "vigésimo", "trigésimo", "quadragésimo", "quinquagésimo",
+	"sexagésimo", "septuagésimo", "octogésimo", "nonagésimo"}
+
+var ptOrdinalHundreds = []string{"", "centésimo", "ducentésimo", "trecentésimo", "quadringentésimo",
+	"quingentésimo", "sexcentésimo", "septingentésimo", "octingentésimo", "noningentésimo"}
+
+var ptMonths = []string{"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+	"julho", "agosto", "setembro", "outubro", "novembro", "dezembro"}
+
+// ptCurrencies mapeia o símbolo para os nomes da moeda e da fração
+var ptCurrencies = map[string][4]string{
+	"R$":  {"real", "reais", "centavo", "centavos"},
+	"US$": {"dólar", "dólares", "centavo", "centavos"},
+	"$":   {"dólar", "dólares", "centavo", "centavos"},
+	"€":   {"euro", "euros", "cêntimo", "cêntimos"},
+}
+
+var ptAbbreviations = map[string]string{
+	"sr": "senhor", "sra": "senhora", "srta": "senhorita",
+	"dr": "doutor", "dra": "doutora", "prof": "professor", "profa": "professora",
+	"eng": "engenheiro", "av": "avenida", "pág": "página", "págs": "páginas",
+	"tel": "telefone", "etc": "et cetera", "aprox": "aproximadamente",
+	"depto": "departamento", "exmo": "excelentíssimo", "exma": "excelentíssima",
+	"máx": "máximo", "mín": "mínimo",
+}
+
+// O número em português usa ponto como separador de milhar e vírgula como
+// separador decimal: 1.234,56. Ponto seguido de uma ou duas casas não é
+// separador de milhar e é lido como decimal: versão 2.0, nota 3.5.
+const ptNumber = `\d{1,3}(?:\.\d{3})+(?:,\d+)?|\d+\.\d{1,2}\b|\d+(?:,\d+)?`
+
+// ptThousands é um número apenas com separadores de milhar
+var ptThousands = regexp.MustCompile(`^\d{1,3}(?:\.\d{3})+$`)
+
+var ptRules = []rule{
+	abbreviationRule(ptAbbreviations),
+	{
+		re: regexp.MustCompile(`(?i)\bn\.?\s?[º°]`),
+		replace: func(m []string) (string, bool) {
+			return "número", true
+		},
+	},
+	// CNPJ e CPF
+	{
+		re: regexp.MustCompile(`\b\d{2}\.\d{3}\.\d{3}/\d{4}-\d{2}\b|\b\d{3}\.\d{3}\.\d{3}-\d{2}\b`),
+		replace: func(m []string) (string, bool) {
+			return spellDigits(m[0], ptDigits, "mais"), true
+		},
+	},
+	// Telefones: (11) 98765-4321, +55 11 3456-7890, 98765-4321, 0800 123 4567.
+	// Fixos sem DDD (3456-7890) ficam de fora para não confundir com
+	// intervalos de anos.
+	{
+		re: regexp.MustCompile(`(?:\+55\s?)?\(\d{2}\)\s?\d{4,5}[-\s]?\d{4}\b|(?:\+55\s?)?\b\d{2}\s\d{4,5}-\d{4}\b|\b9\d{4}-\d{4}\b|\b0[38]00[\s-]?\d{3}[\s-]?\d{4}\b`),
+		replace: func(m []string) (string, bool) {
+			return spellDigits(m[0], ptDigits, "mais"), true
+		},
+	},
+	// Valores monetários: R$ 1.234,56, US$ 10, € 3,50, R$ 2 milhões
+	{
+		re:      regexp.MustCompile(`(R\$|US\$|€|\$)\s?(\d{1,3}(?:\.\d{3})+|\d+)(?:,(\d{1,2}))?(?:\s(mil|milhão|milhões|bilhão|bilhões|trilhão|trilhões)\b)?`),
+		replace: ptCurrency,
+	},
+	// Datas: 12/03/2025 e 2025-03-12
+	{
+		re: regexp.MustCompile(`\b(\d{1,2})/(\d{1,2})/(\d{4}|\d{2})\b`),
+		replace: func(m []string) (string, bool) {
+			return ptDate(m[1], m[2], m[3])
+		},
+	},
+	{
+		re: regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`),
+		replace: func(m []string) (string, bool) {
+			return ptDate(m[3], m[2], m[1])
+		},
+	},
+	// Horários: 14h30, 14h, 14h30min, 14:30, 14:30:15
+	{
+		re: regexp.MustCompile(`\b(\d{1,2})h(?:(\d{2})(?:min)?)?\b`),
+		replace: func(m []string) (string, bool) {
+			return ptTime(m[1], m[2], "")
+		},
+	},
+	{
+		re: regexp.MustCompile(`\b(\d{1,2}):(\d{2})(?::(\d{2}))?\b`),
+		replace: func(m []string) (string, bool) {
+			return ptTime(m[1], m[2], m[3])
+		},
+	},
+	// Ordinais: 1º, 2ª, 3.º
+	{
+		re: regexp.MustCompile(`\b(\d{1,4})\.?([ºª])`),
+		replace: func(m []string) (string, bool) {
+			n, _ := strconv.Atoi(m[1])
+			if n == 0 {
+				return "", false
+			}
+			return ptOrdinal(n, m[2] == "ª"), true
+		},
+	},
+	// Porcentagens: 50%, 12,5 %
+	{
+		re: regexp.MustCompile(`(` + ptNumber + `)\s?%`),
+		replace: func(m []string) (string, bool) {
+			return ptReadNumber(m[1], false) + " por cento", true
+		},
+	},
+	// Intervalos (1999-2000) e números negativos
+	{
+		re: regexp.MustCompile(`(\d)\s?-\s?(\d)`),
+		replace: func(m []string) (string, bool) {
+			return m[1] + " a " + m[2], true
+		},
+	},
+	{
+		re: regexp.MustCompile(`(^|[\s(])-(\d)`),
+		replace: func(m []string) (string, bool) {
+			return m[1] + "menos " + m[2], true
+		},
+	},
+	// Versões e endereços IP (1.10.2, 192.168.0.1), lidos parte a parte
+	{
+		re: regexp.MustCompile(`\b\d+(?:\.\d+){2,}\b`),
+		replace: func(m []string) (string, bool) {
+			if ptThousands.MatchString(m[0]) {
+				return "", false
+			}
+			parts := strings.Split(m[0], ".")
+			spoken := []string{ptReadNumber(parts[0], false)}
+			for _, part := range parts[1:] {
+				spoken = append(spoken, ptFraction(part))
+			}
+			return strings.Join(spoken, " ponto "), true
+		},
+	},
+	{
+		re: regexp.MustCompile(ptNumber),
+		replace: func(m []string) (string, bool) {
+			return ptReadNumber(m[0], false), true
+		},
+	},
+}
+
+// Normalize escreve por extenso números, valores, datas, horários,
+// ordinais, porcentagens, telefones, CPF/CNPJ e abreviações comuns
+func (PortugueseBR) Normalize(text string) string {
+	return applyRules(text, ptRules)
+}
+
+// ptCardinal escreve n por extenso. feminine concorda o número com
+// substantivos femininos ("duas horas", "duzentas pessoas").
+func ptCardinal(n int64, feminine bool) string {
+	if n == 0 {
+		return "zero"
+	}
+	if n < 0 {
+		return "menos " + ptCardinal(-n, feminine)
+	}
+
+	var groups []int
+	for ; n > 0; n /= 1000 {
+		groups = append(groups, int(n%1000))
+	}
+
+	var parts []string
+	lastGroup := 0
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g == 0 {
+			continue
+		}
+		switch {
+		case i == 0:
+			parts = append(parts, ptBelowThousand(g, feminine))
+		case i == 1 && g == 1:
+			parts = append(parts, "mil")
+		case i == 1:
+			parts = append(parts, ptBelowThousand(g, feminine)+" mil")
+		default:
+			scale := ptScales[i-2]
+			name := scale[1]
+			if g == 1 {
+				name = scale[0]
+			}
+			parts = append(parts, ptBelowThousand(g, false)+" "+name)
+		}
+		lastGroup = g
+	}
+
+	// O último grupo é ligado por "e" quando é menor que cem ou uma centena
+	// exata: "mil e duzentos", "mil duzentos e trinta"
+	if len(parts) > 1 && (lastGroup < 100 || lastGroup%100 == 0) {
+		return strings.Join(parts[:len(parts)-1], " ") + " e " + parts[len(parts)-1]
+	}
+	return strings.Join(parts, " ")
+}
+
+func ptBelowThousand(n int, feminine bool) string {
+	if n == 100 {
+		return "cem"
+	}
+
+	var parts []string
+	if h := n / 100; h > 0 {
+		hundreds := ptHundreds[h]
+		if feminine && h > 1 {
+			hundreds = strings.TrimSuffix(hundreds, "os") + "as"
+		}
+		parts = append(parts, hundreds)
+	}
+	switch rest := n % 100; {
+	case rest == 0:
+	case rest < 20:
+		parts = append(parts, ptUnit(rest, feminine))
+	default:
+		parts = append(parts, ptTens[rest/10])
+		if rest%10 > 0 {
+			parts = append(parts, ptUnit(rest%10, feminine))
+		}
+	}
+	return strings.Join(parts, " e ")
+}
+
+func ptUnit(n int, feminine bool) string {
+	if feminine {
+		switch n {
+		case 1:
+			return "uma"
+		case 2:
+			return "duas"
+		}
+	}
+	return ptUnits[n]
+}
+
+// ptOrdinal escreve o ordinal por extenso até 9999; acima disso usa o
+// cardinal
+func ptOrdinal(n int, feminine bool) string {
+	if n <= 0 || n > 9999 {
+		return ptCardinal(int64(n), feminine)
+	}
+
+	var parts []string
+	switch thousands := n / 1000; {
+	case thousands == 1:
+		parts = append(parts, "milésimo")
+	case thousands > 1:
+		parts = append(parts, ptCardinal(int64(thousands), false)+" milésimo")
+	}
+	n %= 1000
+	if h := n / 100; h > 0 {
+		parts = append(parts, ptOrdinalHundreds[h])
+	}
+	if t := n % 100 / 10; t > 0 {
+		parts = append(parts, ptOrdinalTens[t])
+	}
+	if u := n % 10; u > 0 {
+		parts = append(parts, ptOrdinalUnits[u])
+	}
+
+	if feminine {
+		for i, part := range parts {
+			parts[i] = strings.TrimSuffix(part, "o") + "a"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ptReadNumber lê um número no formato brasileiro (1.234,56) ou com ponto
+// decimal de uma ou duas casas (3.5)
+func ptReadNumber(s string, feminine bool) string {
+	if integer, fraction, ok := strings.Cut(s, "."); ok && !ptThousands.MatchString(s) && !strings.Contains(s, ",") {
+		return ptReadNumber(integer, false) + " ponto " + ptFraction(fraction)
+	}
+
+	integer, fraction, hasFraction := strings.Cut(s, ",")
+	integer = strings.ReplaceAll(integer, ".", "")
+
+	var spoken string
+	if readAsDigits(integer) {
+		spoken = spellDigits(integer, ptDigits, "mais")
+	} else {
+		n, _ := strconv.ParseInt(integer, 10, 64)
+		spoken = ptCardinal(n, feminine && !hasFraction)
+	}
+	if hasFraction {
+		spoken += " vírgula " + ptFraction(fraction)
+	}
+	return spoken
+}
+
+// ptFraction lê a parte decimal: zeros à esquerda um a um e o restante como
+// número ("05" é "zero cinco"); partes longas são lidas dígito a dígito
+func ptFraction(digits string) string {
+	if len(digits) > 3 {
+		return spellDigits(digits, ptDigits, "mais")
+	}
+	trimmed := strings.TrimLeft(digits, "0")
+	var parts []string
+	for i := 0; i < len(digits)-len(trimmed); i++ {
+		parts = append(parts, "zero")
+	}
+	if trimmed != "" {
+		n, _ := strconv.ParseInt(trimmed, 10, 64)
+		parts = append(parts, ptCardinal(n, false))
+	}
+	return strings.Join(parts, " ")
+}
+
+func ptCurrency(m []string) (string, bool) {
+	names := ptCurrencies[m[1]]
+	integer := strings.ReplaceAll(m[2], ".", "")
+	if len(integer) > maxDigits {
+		return "", false
+	}
+	n, _ := strconv.ParseInt(integer, 10, 64)
+
+	cents := 0
+	if m[3] != "" {
+		cents, _ = strconv.Atoi(m[3])
+		if len(m[3]) == 1 {
+			cents *= 10
+		}
+	}
+
+	// R$ 2 milhões, R$ 1,5 milhão
+	if scale := m[4]; scale != "" {
+		spoken := ptCardinal(n, false)
+		if m[3] != "" {
+			spoken += " vírgula " + ptFraction(m[3])
+		}
+		spoken += " " + scale
+		if scale != "mil" {
+			spoken += " de"
+		}
+		return spoken + " " + names[1], true
+	}
+
+	var parts []string
+	if n > 0 || cents == 0 {
+		name := names[1]
+		if n == 1 {
+			name = names[0]
+		}
+		// Milhões exatos pedem "de": "um milhão de reais"
+		if n >= 1000000 && n%1000000 == 0 {
+			name = "de " + name
+		}
+		parts = append(parts, ptCardinal(n, false)+" "+name)
+	}
+	if cents > 0 {
+		name := names[3]
+		if cents == 1 {
+			name = names[2]
+		}
+		parts = append(parts, ptCardinal(int64(cents), false)+" "+name)
+	}
+	return strings.Join(parts, " e "), true
+}
+
+func ptDate(d, m, y string) (string, bool) {
+	day, _ := strconv.Atoi(d)
+	month, _ := strconv.Atoi(m)
+	year, _ := strconv.Atoi(y)
+	if day < 1 || month < 1 || month > 12 || day > ptDaysIn(month, year, len(y) == 2) {
+		return "", false
+	}
+
+	spoken := ptCardinal(int64(day), false)
+	if day == 1 {
+		spoken = "primeiro"
+	}
+	return spoken + " de " + ptMonths[month-1] + " de " + ptCardinal(int64(year), false), true
+}
+
+// ptDaysIn retorna a quantidade de dias do mês. Anos com dois dígitos são
+// considerados deste século para identificar os bissextos.
+func ptDaysIn(month, year int, shortYear bool) int {
+	if shortYear {
+		year += 2000
+	}
+	// O dia zero do mês seguinte é o último dia do mês
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// ptTime lê o horário com hora e, quando houver, minutos e segundos
+func ptTime(hour, minute, second string) (string, bool) {
+	h, _ := strconv.Atoi(hour)
+	if h > 23 {
+		return "", false
+	}
+	parts := []string{ptCount(h, true, "hora", "horas")}
+
+	for _, unit := range []struct {
+		value            string
+		singular, plural string
+	}{{minute, "minuto", "minutos"}, {second, "segundo", "segundos"}} {
+		if unit.value == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(unit.value)
+		if n > 59 {
+			return "", false
+		}
+		if n > 0 {
+			parts = append(parts, ptCount(n, false, unit.singular, unit.plural))
+		}
+	}
+	return strings.Join(parts, " e "), true
+}
+
+// ptCount lê a quantidade seguida do substantivo no singular (zero e um)
+// ou no plural
+func ptCount(n int, feminine bool, singular, plural string) string {
+	if n <= 1 {
+		return ptCardinal(int64(n), feminine) + " " + singular
+	}
+	return ptCardinal(int64(n), feminine) + " " + plural
+}
@@ -0,0 +1,43 @@
+package normalize
+
+import "testing"
+
+func TestPortugueseBR(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		// Exemplos do pedido original
+		{"R$ 1.234,56", "mil duzentos e trinta e quatro reais e cinquenta e seis centavos"},
+		{"12/03/2025", "doze de março de dois mil e vinte e cinco"},
+		{"14h30", "catorze horas e trinta minutos"},
+		{"CPF 123.456.789-00", "CPF um dois três, quatro cinco seis, sete oito nove, zero zero"},
+		{"Dr. Silva", "doutor Silva"},
+		{"1º lugar", "primeiro lugar"},
+		{"50%", "cinquenta por cento"},
+		{"(11) 98765-4321", "um um, nove oito sete seis cinco, quatro três dois um"},
+
+		// Separador de milhar e decimais
+		{"1.234 pessoas", "mil duzentos e trinta e quatro pessoas"},
+		{"1.234,5", "mil duzentos e trinta e quatro vírgula cinco"},
+		{"12,5 %", "doze vírgula cinco por cento"},
+		{"versão 2.0", "versão dois ponto zero"},
+		{"nota 3.5", "nota três ponto cinco"},
+		{"2.05", "dois ponto zero cinco"},
+		{"12.5%", "doze ponto cinco por cento"},
+		{"versão 1.10.2", "versão um ponto dez ponto dois"},
+		{"1.234.567", "um milhão duzentos e trinta e quatro mil quinhentos e sessenta e sete"},
+
+		// Datas, validando o dia no mês; datas inexistentes não são lidas
+		// como datas
+		{"2025-03-12", "doze de março de dois mil e vinte e cinco"},
+		{"29/02/2024", "vinte e nove de fevereiro de dois mil e vinte e quatro"},
+		{"31/02/2024", "trinta e um/zero dois/dois mil e vinte e quatro"},
+		{"29/02/2023", "vinte e nove/zero dois/dois mil e vinte e três"},
+		{"31/04/2025", "trinta e um/zero quatro/dois mil e vinte e cinco"},
+	}
+	for _, tt := range tests {
+		if got := (PortugueseBR{}).Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, esperado %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package normalize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxDigits é o maior número lido por extenso; números maiores (códigos,
+// protocolos) são lidos dígito a dígito
+const maxDigits = 15
+
+// rule substitui cada ocorrência de re pelo texto retornado por replace.
+// m contém a ocorrência e os grupos; replace retorna false para manter o
+// trecho original.
+type rule struct {
+	re      *regexp.Regexp
+	replace func(m []string) (string, bool)
+}
+
+func applyRules(s string, rules []rule) string {
+	for _, r := range rules {
+		s = r.apply(s)
+	}
+	return s
+}
+
+func (r rule) apply(s string) string {
+	matches := r.re.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range matches {
+		groups := make([]string, len(loc)/2)
+		for i := range groups {
+			if loc[2*i] >= 0 {
+				groups[i] = s[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		spoken, ok := r.replace(groups)
+		if !ok {
+			continue
+		}
+
+		b.WriteString(s[last:loc[0]])
+		// Separa a forma falada de letras coladas ("mp3", "5G")
+		if before, _ := utf8.DecodeLastRuneInString(s[:loc[0]]); isLetter(before) && startsWithLetter(spoken) {
+			b.WriteByte(' ')
+		}
+		b.WriteString(spoken)
+		if after, _ := utf8.DecodeRuneInString(s[loc[1]:]); isWordRune(after) && endsWithLetter(spoken) {
+			b.WriteByte(' ')
+		}
+		last = loc[1]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// abbreviationRule substitui as abreviações (sem o ponto final) de
+// expansions, sem diferenciar maiúsculas
+func abbreviationRule(expansions map[string]string) rule {
+	keys := make([]string, 0, len(expansions))
+	for k := range expansions {
+		keys = append(keys, k)
+	}
+	// As mais longas primeiro, para que "sra" não seja lida como "sr"
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+
+	quoted := make([]string, len(keys))
+	for i, k := range keys {
+		quoted[i] = regexp.QuoteMeta(k)
+	}
+	return rule{
+		re: regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)(?:(\.\s*$)|\.)`),
+		replace: func(m []string) (string, bool) {
+			expansion, ok := expansions[strings.ToLower(m[1])]
+			if m[2] != "" {
+				// O ponto também encerra a frase
+				expansion += "."
+			}
+			return expansion, ok
+		},
+	}
+}
+
+// spellDigits lê os dígitos um a um. Grupos separados por pontuação
+// ("123.456-78") são lidos com uma pausa curta entre eles.
+func spellDigits(s string, digits [10]string, plus string) string {
+	var groups []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			groups = append(groups, strings.Join(current, " "))
+			current = nil
+		}
+	}
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			current = append(current, digits[r-'0'])
+		case r == '+':
+			flush()
+			current = append(current, plus)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return strings.Join(groups, ", ")
+}
+
+// readAsDigits indica se o número deve ser lido dígito a dígito: zeros à
+// esquerda ("007") ou números longos demais
+func readAsDigits(digits string) bool {
+	return len(digits) > maxDigits || (len(digits) > 1 && digits[0] == '0')
+}
+
+func isLetter(r rune) bool {
+	return r != utf8.RuneError && unicode.IsLetter(r)
+}
+
+func isWordRune(r rune) bool {
+	return isLetter(r) || unicode.IsDigit(r)
+}
+
+func startsWithLetter(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return isLetter(r)
+}
+
+func endsWithLetter(s string) bool {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return isLetter(r)
+}
@@ -0,0 +1,526 @@
+package prompts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"tts-api/internal/audio"
+	"tts-api/internal/config"
+	"tts-api/internal/ssml"
+	"tts-api/internal/voice"
+)
+
+// ErrNotFound é retornado para ids que não constam no pacote
+var ErrNotFound = errors.New("prompt não encontrado")
+
+// Status é a situação da renderização de um prompt
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRendering Status = "rendering"
+	StatusReady     Status = "ready"
+	StatusFailed    Status = "failed"
+)
+
+// Info descreve um prompt e seu áudio renderizado
+type Info struct {
+	ID         string     `json:"id"`
+	Voice      string     `json:"voice"`
+	Text       string     `json:"text"`
+	Status     Status     `json:"status"`
+	Hash       string     `json:"hash,omitempty"` // Muda com o texto, os parâmetros, o léxico ou o modelo da voz
+	MimeType   string     `json:"mime_type,omitempty"`
+	Duration   float64    `json:"duration,omitempty"`
+	SampleRate int        `json:"sample_rate,omitempty"`
+	RenderedAt *time.Time `json:"rendered_at,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// metadata é gravado em <id>.json ao lado do áudio
+type metadata struct {
+	Hash       string    `json:"hash"`
+	MimeType   string    `json:"mime_type"`
+	Duration   float64   `json:"duration"`
+	SampleRate int       `json:"sample_rate"`
+	RenderedAt time.Time `json:"rendered_at"`
+}
+
+type entry struct {
+	id     string
+	prompt Prompt
+	hash   string
+	render sync.Mutex // impede renderizações simultâneas do mesmo prompt
+
+	// Protegidos por Library.mu
+	status Status
+	meta   *metadata
+	err    string
+}
+
+// Library mantém os prompts do pacote renderizados em disco. O pacote é
+// relido periodicamente e os prompts cujo hash mudou (texto, parâmetros,
+// léxico ou modelo da voz) são renderizados novamente em segundo plano.
+type Library struct {
+	voices   *voice.Manager
+	cfg      *config.Config
+	path     string
+	dir      string
+	interval time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*entry
+
+	wake chan struct{}
+	ctx  context.Context
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+}
+
+// New carrega o pacote de cfg.PromptsFile e inicia a renderização em
+// segundo plano. Retorna nil quando nenhum pacote foi configurado.
+func New(vm *voice.Manager, cfg *config.Config) (*Library, error) {
+	if cfg.PromptsFile == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(cfg.PromptsDir, 0755); err != nil {
+		return nil, fmt.Errorf("erro ao criar o diretório de prompts: %v", err)
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	l := &Library{
+		voices:   vm,
+		cfg:      cfg,
+		path:     cfg.PromptsFile,
+		dir:      cfg.PromptsDir,
+		interval: time.Duration(cfg.PromptsCheckSeconds) * time.Second,
+		entries:  make(map[string]*entry),
+		wake:     make(chan struct{}, 1),
+		ctx:      ctx,
+		stop:     stop,
+	}
+	if err := l.Reload(); err != nil {
+		stop()
+		return nil, err
+	}
+
+	l.wg.Add(2)
+	go l.worker()
+	go l.watch()
+	return l, nil
+}
+
+// Reload relê o pacote e agenda a renderização dos prompts novos ou
+// alterados. Prompts removidos do pacote têm os arquivos apagados.
+func (l *Library) Reload() error {
+	pack, err := LoadPack(l.path)
+	if err != nil {
+		return err
+	}
+
+	versions := make(map[string]string)
+	hashes := make(map[string]string, len(pack.Prompts))
+	hashErrors := make(map[string]error)
+	for id, p := range pack.Prompts {
+		hashes[id], hashErrors[id] = l.hash(p, versions)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	pending := 0
+	entries := make(map[string]*entry, len(pack.Prompts))
+	for id, p := range pack.Prompts {
+		if current, ok := l.entries[id]; ok && current.hash == hashes[id] && hashErrors[id] == nil {
+			entries[id] = current
+			continue
+		}
+
+		e := &entry{id: id, prompt: p, hash: hashes[id], status: StatusPending}
+		switch {
+		case hashErrors[id] != nil:
+			e.status, e.err = StatusFailed, hashErrors[id].Error()
+		default:
+			if meta, ok := l.readMetadata(id); ok && meta.Hash == e.hash {
+				e.status, e.meta = StatusReady, meta
+			} else {
+				pending++
+			}
+		}
+		entries[id] = e
+	}
+	for id := range l.entries {
+		if _, ok := entries[id]; !ok {
+			l.removeFiles(id, "")
+		}
+	}
+	l.entries = entries
+
+	if pending > 0 {
+		log.Printf("Prompts: %d de %d a renderizar", pending, len(entries))
+		l.signal()
+	}
+	return nil
+}
+
+// List retorna a situação de todos os prompts em ordem de id
+func (l *Library) List() []Info {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	infos := make([]Info, 0, len(l.entries))
+	for _, e := range l.entries {
+		infos = append(infos, e.info())
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// Get retorna o prompt e o caminho do áudio, renderizando-o na hora se
+// ainda não estiver pronto
+func (l *Library) Get(ctx context.Context, id string) (Info, string, error) {
+	l.mu.Lock()
+	e, ok := l.entries[id]
+	l.mu.Unlock()
+	if !ok {
+		return Info{}, "", ErrNotFound
+	}
+
+	if err := l.render(ctx, e); err != nil {
+		l.mu.Lock()
+		info := e.info()
+		l.mu.Unlock()
+		return info, "", err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return e.info(), l.audioPath(id, e.hash), nil
+}
+
+// Close interrompe a renderização em segundo plano
+func (l *Library) Close() {
+	l.stop()
+	l.wg.Wait()
+}
+
+// render sintetiza o prompt se ele não estiver pronto
+func (l *Library) render(ctx context.Context, e *entry) error {
+	e.render.Lock()
+	defer e.render.Unlock()
+
+	l.mu.Lock()
+	if e.status == StatusReady {
+		l.mu.Unlock()
+		return nil
+	}
+	if e.hash == "" {
+		// O hash não pôde ser calculado (voz inexistente, por exemplo)
+		err := errors.New(e.err)
+		l.mu.Unlock()
+		return err
+	}
+	e.status = StatusRendering
+	l.mu.Unlock()
+
+	meta, err := l.synthesize(ctx, e)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err != nil {
+		e.status, e.err = StatusFailed, err.Error()
+		return err
+	}
+	e.status, e.meta, e.err = StatusReady, meta, ""
+	return nil
+}
+
+// synthesize renderiza o prompt e grava o áudio e os metadados
+func (l *Library) synthesize(ctx context.Context, e *entry) (*metadata, error) {
+	p := e.prompt
+	opts, err := p.options()
+	if err != nil {
+		return nil, err
+	}
+	req := voice.Request{
+		Voice:             p.Voice,
+		Text:              p.Text,
+		Speaker:           p.Speaker,
+		Options:           opts,
+		SkipNormalization: p.Normalize != nil && !*p.Normalize,
+		Phonemes:          p.TextType == "phonemes",
+	}
+
+	var wavData []byte
+	switch p.TextType {
+	case "", "text", "phonemes":
+		wavData, err = l.voices.Synthesize(ctx, req)
+	case "ssml":
+		var language string
+		if info, ok := l.voices.Voice(p.Voice); ok {
+			language = info.Language
+		}
+		var segments []ssml.Segment
+		if segments, err = ssml.Parse(p.Text, language); err == nil {
+			wavData, err = ssml.Render(ctx, l.voices, req, segments)
+		}
+	default:
+		err = fmt.Errorf("text_type inválido: %q (use text, ssml ou phonemes)", p.TextType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	encoding, encodeOpts, err := l.outputOptions(p)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := audio.Encode(wavData, encoding, encodeOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &metadata{
+		Hash:       e.hash,
+		MimeType:   encoded.MimeType,
+		Duration:   encoded.Duration,
+		SampleRate: encoded.SampleRate,
+		RenderedAt: time.Now().UTC(),
+	}
+	if err := writeFile(l.audioPath(e.id, e.hash), encoded.Data); err != nil {
+		return nil, err
+	}
+	data, _ := json.MarshalIndent(meta, "", "  ")
+	if err := writeFile(filepath.Join(l.dir, e.id+".json"), data); err != nil {
+		return nil, err
+	}
+	l.removeFiles(e.id, e.hash)
+	return meta, nil
+}
+
+// outputOptions monta o formato de saída com os padrões da configuração
+func (l *Library) outputOptions(p Prompt) (audio.Encoding, audio.Options, error) {
+	opts := audio.Options{
+		Opus: audio.OpusOptions{Bitrate: l.cfg.OpusBitrate, SampleRate: l.cfg.OpusSampleRate},
+		MP3:  audio.MP3Options{Bitrate: l.cfg.MP3Bitrate},
+	}
+	encoding := audio.EncodingWAV
+	if p.Encoding != "" {
+		var err error
+		if encoding, err = audio.ParseEncoding(p.Encoding); err != nil {
+			return "", opts, err
+		}
+	}
+	if p.SampleRate != nil {
+		opts.SampleRate = *p.SampleRate
+		opts.Opus.SampleRate = *p.SampleRate
+	}
+
+	var source int
+	if info, exists := l.voices.Voice(p.Voice); exists {
+		source = info.SampleRate
+	}
+	if p.Bitrate != nil {
+		opts.Opus.Bitrate = *p.Bitrate
+		opts.MP3.Bitrate = *p.Bitrate
+	} else {
+		opts.FitMP3Bitrate(encoding, source)
+	}
+	return encoding, opts, opts.ValidateFor(encoding, source)
+}
+
+// hash identifica o que determina o áudio do prompt: os parâmetros, o
+// texto após o léxico e a normalização e as versões do modelo e do léxico
+// da voz, que o SSML só aplica na síntese.
+// versions guarda as versões já consultadas durante o Reload.
+func (l *Library) hash(p Prompt, versions map[string]string) (string, error) {
+	info, ok := l.voices.Voice(p.Voice)
+	if !ok {
+		return "", fmt.Errorf("voz %s não encontrada", p.Voice)
+	}
+
+	version, ok := versions[p.Voice]
+	if !ok {
+		var err error
+		if version, err = l.voices.ModelVersion(p.Voice); err != nil {
+			return "", err
+		}
+		versions[p.Voice] = version
+	}
+
+	text := p.Text
+	if p.TextType == "" || p.TextType == "text" {
+		text = l.voices.PrepareText(info, text, p.Normalize != nil && !*p.Normalize)
+	}
+	params, _ := json.Marshal(p)
+
+	h := sha256.New()
+	h.Write(params)
+	h.Write([]byte("\x00" + text + "\x00" + version + "\x00" + l.voices.Lexicons().Version(p.Voice)))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// worker renderiza os prompts pendentes sempre que é sinalizado
+func (l *Library) worker() {
+	defer l.wg.Done()
+	for {
+		select {
+		case <-l.wake:
+		case <-l.ctx.Done():
+			return
+		}
+
+		for _, e := range l.pending() {
+			if l.ctx.Err() != nil {
+				return
+			}
+			if err := l.render(l.ctx, e); err != nil {
+				log.Printf("Erro ao renderizar o prompt %s: %v", e.id, err)
+			}
+		}
+	}
+}
+
+// watch relê o pacote periodicamente, o que também detecta mudanças no
+// léxico e nos modelos das vozes
+func (l *Library) watch() {
+	defer l.wg.Done()
+	if l.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.Reload(); err != nil {
+				log.Printf("Aviso: pacote de prompts mantido: %v", err)
+			}
+		case <-l.ctx.Done():
+			return
+		}
+	}
+}
+
+func (l *Library) pending() []*entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var pending []*entry
+	for _, id := range sortedIDs(l.entries) {
+		if e := l.entries[id]; e.status == StatusPending {
+			pending = append(pending, e)
+		}
+	}
+	return pending
+}
+
+func (l *Library) signal() {
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (l *Library) readMetadata(id string) (*metadata, bool) {
+	data, err := os.ReadFile(filepath.Join(l.dir, id+".json"))
+	if err != nil {
+		return nil, false
+	}
+	var meta metadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, false
+	}
+	if _, err := os.Stat(l.audioPath(id, meta.Hash)); err != nil {
+		return nil, false
+	}
+	return &meta, true
+}
+
+// audioVersionPattern corresponde ao trecho do hash no nome dos áudios
+var audioVersionPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// audioPath inclui o hash no nome, para que uma renderização antiga em
+// andamento nunca sobrescreva a atual
+func (l *Library) audioPath(id, hash string) string {
+	return filepath.Join(l.dir, id+"."+hash[:16]+".audio")
+}
+
+// removeFiles apaga os áudios do prompt exceto o do hash informado; com
+// hash vazio apaga também os metadados
+func (l *Library) removeFiles(id, hash string) {
+	files, _ := filepath.Glob(filepath.Join(l.dir, id+".*.audio"))
+	for _, file := range files {
+		// Ids podem conter ".": menu.*.audio também encontra os áudios de
+		// menu.principal, que não são do prompt
+		version := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), id+"."), ".audio")
+		if !audioVersionPattern.MatchString(version) {
+			continue
+		}
+		if hash == "" || file != l.audioPath(id, hash) {
+			os.Remove(file)
+		}
+	}
+	if hash == "" {
+		os.Remove(filepath.Join(l.dir, id+".json"))
+	}
+}
+
+func (e *entry) info() Info {
+	info := Info{
+		ID:     e.id,
+		Voice:  e.prompt.Voice,
+		Text:   e.prompt.Text,
+		Status: e.status,
+		Hash:   e.hash,
+		Error:  e.err,
+	}
+	if e.meta != nil {
+		renderedAt := e.meta.RenderedAt
+		info.MimeType = e.meta.MimeType
+		info.Duration = e.meta.Duration
+		info.SampleRate = e.meta.SampleRate
+		info.RenderedAt = &renderedAt
+	}
+	return info
+}
+
+// writeFile grava via arquivo temporário para não expor áudio incompleto
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("erro ao gravar o prompt: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("erro ao gravar o prompt: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("erro ao gravar o prompt: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("erro ao gravar o prompt: %v", err)
+	}
+	return nil
+}
+
+func sortedIDs(entries map[string]*entry) []string {
+	ids := make([]string, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
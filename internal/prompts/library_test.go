@@ -0,0 +1,56 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestRemoveFilesMatchesOnlyTheID(t *testing.T) {
+	l := &Library{dir: t.TempDir()}
+	const (
+		current = "0123456789abcdef0123456789abcdef"
+		old     = "fedcba9876543210fedcba9876543210"
+	)
+
+	files := []string{
+		"menu." + current[:16] + ".audio",
+		"menu." + old[:16] + ".audio",
+		"menu.json",
+		"menu.principal." + current[:16] + ".audio",
+		"menu.principal.json",
+	}
+	for _, name := range files {
+		if err := os.WriteFile(filepath.Join(l.dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l.removeFiles("menu", current)
+	assertFiles(t, l.dir, "menu."+current[:16]+".audio", "menu.json", "menu.principal."+current[:16]+".audio", "menu.principal.json")
+
+	l.removeFiles("menu", "")
+	assertFiles(t, l.dir, "menu.principal."+current[:16]+".audio", "menu.principal.json")
+}
+
+func assertFiles(t *testing.T, dir string, want ...string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("arquivos %v, esperado %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("arquivos %v, esperado %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package prompts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"tts-api/internal/voice"
+
+	"gopkg.in/yaml.v3"
+)
+
+// idPattern restringe os ids, que também nomeiam os arquivos renderizados
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Prompt é uma frase fixa a ser renderizada. Campos vazios usam os
+// defaults do pacote.
+type Prompt struct {
+	Text      string `yaml:"text" json:"text"`
+	Voice     string `yaml:"voice" json:"voice,omitempty"`
+	Speaker   string `yaml:"speaker" json:"speaker,omitempty"`
+	TextType  string `yaml:"text_type" json:"text_type,omitempty"` // text, ssml ou phonemes
+	Normalize *bool  `yaml:"normalize" json:"normalize,omitempty"`
+
+	Encoding   string `yaml:"encoding" json:"encoding,omitempty"`
+	Bitrate    *int   `yaml:"bitrate" json:"bitrate,omitempty"`
+	SampleRate *int   `yaml:"sample_rate" json:"sample_rate,omitempty"`
+
+	Speed           *float64 `yaml:"speed" json:"speed,omitempty"`
+	LengthScale     *float64 `yaml:"length_scale" json:"length_scale,omitempty"`
+	NoiseScale      *float64 `yaml:"noise_scale" json:"noise_scale,omitempty"`
+	NoiseW          *float64 `yaml:"noise_w" json:"noise_w,omitempty"`
+	SentenceSilence *float64 `yaml:"sentence_silence" json:"sentence_silence,omitempty"`
+}
+
+// Pack é o arquivo de prompts: ids mapeados para textos, com valores
+// padrão para voz, formato e prosódia
+type Pack struct {
+	Defaults Prompt            `yaml:"defaults" json:"defaults"`
+	Prompts  map[string]Prompt `yaml:"prompts" json:"prompts"`
+}
+
+// LoadPack lê um pacote em YAML ou JSON, conforme a extensão do arquivo,
+// e aplica os defaults a cada prompt
+func LoadPack(path string) (*Pack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler o pacote de prompts: %v", err)
+	}
+
+	var pack Pack
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&pack)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&pack)
+	default:
+		return nil, fmt.Errorf("pacote de prompts %s: use a extensão .yaml, .yml ou .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pacote de prompts %s inválido: %v", path, err)
+	}
+
+	for id, p := range pack.Prompts {
+		if !idPattern.MatchString(id) {
+			return nil, fmt.Errorf("id de prompt inválido: %q", id)
+		}
+		p = p.withDefaults(pack.Defaults)
+		if strings.TrimSpace(p.Text) == "" {
+			return nil, fmt.Errorf("prompt %s sem texto", id)
+		}
+		if p.Voice == "" {
+			return nil, fmt.Errorf("prompt %s sem voz", id)
+		}
+		pack.Prompts[id] = p
+	}
+	return &pack, nil
+}
+
+// IDs retorna os ids em ordem alfabética
+func (p *Pack) IDs() []string {
+	ids := make([]string, 0, len(p.Prompts))
+	for id := range p.Prompts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (p Prompt) withDefaults(d Prompt) Prompt {
+	str := func(v *string, def string) {
+		if *v == "" {
+			*v = def
+		}
+	}
+	str(&p.Voice, d.Voice)
+	str(&p.Speaker, d.Speaker)
+	str(&p.TextType, d.TextType)
+	str(&p.Encoding, d.Encoding)
+	if p.Normalize == nil {
+		p.Normalize = d.Normalize
+	}
+	if p.Bitrate == nil {
+		p.Bitrate = d.Bitrate
+	}
+	if p.SampleRate == nil {
+		p.SampleRate = d.SampleRate
+	}
+	// A velocidade e length_scale são alternativos; o default só vale se o
+	// prompt não definir nenhum dos dois
+	if p.Speed == nil && p.LengthScale == nil {
+		p.Speed, p.LengthScale = d.Speed, d.LengthScale
+	}
+	for _, f := range []struct{ v, def **float64 }{
+		{&p.NoiseScale, &d.NoiseScale},
+		{&p.NoiseW, &d.NoiseW},
+		{&p.SentenceSilence, &d.SentenceSilence},
+	} {
+		if *f.v == nil {
+			*f.v = *f.def
+		}
+	}
+	return p
+}
+
+// options converte a prosódia do prompt
+func (p Prompt) options() (voice.Options, error) {
+	opts := voice.Options{
+		LengthScale:     p.LengthScale,
+		NoiseScale:      p.NoiseScale,
+		NoiseW:          p.NoiseW,
+		SentenceSilence: p.SentenceSilence,
+	}
+	if p.Speed != nil {
+		if p.LengthScale != nil {
+			return opts, fmt.Errorf("informe apenas speed ou length_scale")
+		}
+		if err := voice.SpeedRange.Check("speed", *p.Speed); err != nil {
+			return opts, err
+		}
+		lengthScale := 1 / *p.Speed
+		opts.LengthScale = &lengthScale
+	}
+	return opts, opts.Validate()
+}
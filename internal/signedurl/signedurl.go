@@ -0,0 +1,66 @@
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Parâmetros acrescentados à query string pela assinatura
+const (
+	ExpiresParam   = "expires"
+	SignatureParam = "signature"
+)
+
+var (
+	ErrInvalidSignature = errors.New("assinatura inválida")
+	ErrExpired          = errors.New("URL assinada expirada")
+)
+
+// Sign acrescenta a validade (em segundos Unix) e a assinatura HMAC-SHA256
+// aos parâmetros e retorna a query string resultante
+func Sign(secret string, path string, params url.Values, expires time.Time) string {
+	signed := url.Values{}
+	for key, values := range params {
+		if key != SignatureParam {
+			signed[key] = values
+		}
+	}
+	signed.Set(ExpiresParam, strconv.FormatInt(expires.Unix(), 10))
+	signed.Set(SignatureParam, signature(secret, path, signed))
+	return signed.Encode()
+}
+
+// Verify confere a assinatura e a validade dos parâmetros
+func Verify(secret string, path string, params url.Values, now time.Time) error {
+	expected := params.Get(SignatureParam)
+	unsigned := url.Values{}
+	for key, values := range params {
+		if key != SignatureParam {
+			unsigned[key] = values
+		}
+	}
+	if !hmac.Equal([]byte(expected), []byte(signature(secret, path, unsigned))) {
+		return ErrInvalidSignature
+	}
+
+	expires, err := strconv.ParseInt(params.Get(ExpiresParam), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if now.Unix() > expires {
+		return ErrExpired
+	}
+	return nil
+}
+
+// signature assina o caminho e a query string canônica (chaves em ordem)
+func signature(secret, path string, params url.Values) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(path + "?" + params.Encode()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
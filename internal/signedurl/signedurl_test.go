@@ -0,0 +1,62 @@
+package signedurl
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	params := url.Values{"text": {"Olá mundo"}, "voice": {"faber"}}
+	query, err := url.ParseQuery(Sign("segredo", "/synthesize", params, now.Add(time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.Has(ExpiresParam) {
+		t.Fatal("Sign alterou os parâmetros recebidos")
+	}
+
+	tamper := func(key, value string) url.Values {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set(key, value)
+		return q
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		path   string
+		params url.Values
+		now    time.Time
+		want   error
+	}{
+		{"válida", "segredo", "/synthesize", query, now, nil},
+		{"no limite da validade", "segredo", "/synthesize", query, now.Add(time.Hour), nil},
+		{"expirada", "segredo", "/synthesize", query, now.Add(time.Hour + time.Second), ErrExpired},
+		{"outra chave", "outro", "/synthesize", query, now, ErrInvalidSignature},
+		{"outro caminho", "segredo", "/prompts", query, now, ErrInvalidSignature},
+		{"texto alterado", "segredo", "/synthesize", tamper("text", "Outro texto"), now, ErrInvalidSignature},
+		{"validade estendida", "segredo", "/synthesize", tamper(ExpiresParam, "9999999999"), now, ErrInvalidSignature},
+		{"parâmetro incluído", "segredo", "/synthesize", tamper("encoding", "mp3"), now, ErrInvalidSignature},
+		{"sem assinatura", "segredo", "/synthesize", tamper(SignatureParam, ""), now, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		if err := Verify(tt.secret, tt.path, tt.params, tt.now); !errors.Is(err, tt.want) {
+			t.Errorf("%s: %v, esperado %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestSignIgnoresPreviousSignature(t *testing.T) {
+	expires := time.Unix(1700000000, 0)
+	params := url.Values{"text": {"a"}, SignatureParam: {"antiga"}}
+	again := url.Values{"text": {"a"}}
+	if Sign("segredo", "/synthesize", params, expires) != Sign("segredo", "/synthesize", again, expires) {
+		t.Fatal("a assinatura anterior entrou no cálculo")
+	}
+}
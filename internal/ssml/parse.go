@@ -0,0 +1,444 @@
+// Package ssml interpreta o subconjunto de SSML aceito pela API e o converte
+// em segmentos de fala e silêncio sintetizados em sequência.
+package ssml
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"tts-api/internal/text"
+	"unicode"
+)
+
+// MaxBreak é a maior pausa aceita em <break>
+const MaxBreak = 10 * time.Second
+
+// Pausas de <break strength> e após cada parágrafo
+var breakStrengths = map[string]time.Duration{
+	"none":     0,
+	"x-weak":   100 * time.Millisecond,
+	"weak":     250 * time.Millisecond,
+	"medium":   500 * time.Millisecond,
+	"strong":   750 * time.Millisecond,
+	"x-strong": time.Second,
+}
+
+const paragraphBreak = 500 * time.Millisecond
+
+// Velocidades nomeadas de <prosody rate>
+var namedRates = map[string]float64{
+	"x-slow": 0.5,
+	"slow":   0.75,
+	"medium": 1,
+	"fast":   1.25,
+	"x-fast": 1.5,
+}
+
+// Segment é um trecho de fala ou, quando Silence é positivo, uma pausa
+type Segment struct {
+	Text    string
+	Voice   string  // vazio usa a voz da requisição
+	Rate    float64 // multiplicador de velocidade (1 = normal)
+	Silence time.Duration
+}
+
+// ParseError indica SSML malformado e a posição do problema no documento
+type ParseError struct {
+	Line   int
+	Column int
+	Offset int64 // em bytes, a partir do início do documento
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("SSML inválido na linha %d, coluna %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// parser mantém o contexto dos elementos abertos durante a leitura
+type parser struct {
+	input    string
+	decoder  *xml.Decoder
+	language string
+	segments []Segment
+
+	voices []string
+	rates  []float64
+	buf    strings.Builder
+}
+
+// Parse converte um documento <speak> em segmentos. language (pt_BR, en_US,
+// ...) define como datas de <say-as> são lidas; xml:lang no documento tem
+// precedência.
+func Parse(document, language string) ([]Segment, error) {
+	p := &parser{
+		input:    document,
+		decoder:  xml.NewDecoder(strings.NewReader(document)),
+		language: language,
+		rates:    []float64{1},
+		voices:   []string{""},
+	}
+	p.decoder.Strict = true
+
+	root, err := p.root()
+	if err != nil {
+		return nil, err
+	}
+	if lang := attr(root, "lang"); lang != "" {
+		p.language = lang
+	}
+
+	if err := p.parseChildren("speak"); err != nil {
+		return nil, err
+	}
+	p.flushText()
+
+	// Nada além de espaços e comentários pode vir depois de </speak>
+	for {
+		tok, err := p.decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, p.syntaxError(err)
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			if strings.TrimSpace(string(t)) != "" {
+				return nil, p.errorf("conteúdo após </speak>")
+			}
+		case xml.StartElement:
+			return nil, p.errorf("conteúdo após </speak>")
+		}
+	}
+
+	return p.segments, nil
+}
+
+// root lê o elemento <speak>, ignorando a declaração XML e comentários
+func (p *parser) root() (xml.StartElement, error) {
+	for {
+		tok, err := p.next()
+		if err == io.EOF {
+			return xml.StartElement{}, p.errorf("documento SSML vazio")
+		}
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+
+		switch t := tok.(type) {
+		case xml.ProcInst, xml.Comment, xml.Directive:
+			continue
+		case xml.CharData:
+			if strings.TrimSpace(string(t)) == "" {
+				continue
+			}
+		case xml.StartElement:
+			if t.Name.Local == "speak" {
+				return t, nil
+			}
+		}
+		return xml.StartElement{}, p.errorf("o documento deve começar com <speak>")
+	}
+}
+
+// parseChildren lê o conteúdo até o fechamento do elemento atual
+func (p *parser) parseChildren(name string) error {
+	for {
+		tok, err := p.next()
+		if err == io.EOF {
+			return p.errorf("elemento <%s> não foi fechado", name)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.CharData:
+			p.buf.Write(t)
+		case xml.EndElement:
+			return nil
+		case xml.StartElement:
+			if err := p.parseElement(t); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *parser) parseElement(el xml.StartElement) error {
+	switch el.Name.Local {
+	case "break":
+		pause, err := p.breakDuration(el)
+		if err != nil {
+			return err
+		}
+		p.flushText()
+		p.addSilence(pause)
+		return p.skip()
+
+	case "p", "s":
+		p.flushText()
+		if err := p.parseChildren(el.Name.Local); err != nil {
+			return err
+		}
+		p.flushText()
+		if el.Name.Local == "p" {
+			p.addSilence(paragraphBreak)
+		}
+		return nil
+
+	case "prosody":
+		rate, err := p.rate(attr(el, "rate"))
+		if err != nil {
+			return err
+		}
+		p.flushText()
+		p.rates = append(p.rates, p.currentRate()*rate)
+		err = p.parseChildren("prosody")
+		p.flushText()
+		p.rates = p.rates[:len(p.rates)-1]
+		return err
+
+	case "voice":
+		name := attr(el, "name")
+		if name == "" {
+			return p.errorf("<voice> exige o atributo name")
+		}
+		p.flushText()
+		p.voices = append(p.voices, name)
+		err := p.parseChildren("voice")
+		p.flushText()
+		p.voices = p.voices[:len(p.voices)-1]
+		return err
+
+	case "sub":
+		alias := attr(el, "alias")
+		if alias == "" {
+			return p.errorf("<sub> exige o atributo alias")
+		}
+		if _, err := p.innerText("sub"); err != nil {
+			return err
+		}
+		p.buf.WriteString(" " + alias + " ")
+		return nil
+
+	case "say-as":
+		interpretAs := attr(el, "interpret-as")
+		content, err := p.innerText("say-as")
+		if err != nil {
+			return err
+		}
+		spoken, err := p.sayAs(interpretAs, attr(el, "format"), content)
+		if err != nil {
+			return err
+		}
+		p.buf.WriteString(" " + spoken + " ")
+		return nil
+
+	default:
+		// Elementos não suportados (emphasis, mark, ...) são ignorados, mas o
+		// texto contido neles é mantido
+		return p.parseChildren(el.Name.Local)
+	}
+}
+
+// innerText retorna o texto de um elemento que não pode conter outros
+func (p *parser) innerText(name string) (string, error) {
+	var content strings.Builder
+	for {
+		tok, err := p.next()
+		if err == io.EOF {
+			return "", p.errorf("elemento <%s> não foi fechado", name)
+		}
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			content.Write(t)
+		case xml.StartElement:
+			return "", p.errorf("<%s> não pode conter o elemento <%s>", name, t.Name.Local)
+		case xml.EndElement:
+			return strings.TrimSpace(content.String()), nil
+		}
+	}
+}
+
+// skip ignora o conteúdo de um elemento vazio como <break/>
+func (p *parser) skip() error {
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.EndElement:
+			return nil
+		case xml.StartElement:
+			return p.errorf("elemento <%s> inesperado", t.Name.Local)
+		}
+	}
+}
+
+func (p *parser) breakDuration(el xml.StartElement) (time.Duration, error) {
+	if value := attr(el, "time"); value != "" {
+		d, err := parseTime(value)
+		if err != nil {
+			return 0, p.errorf("valor inválido em <break time=%q>", value)
+		}
+		if d < 0 || d > MaxBreak {
+			return 0, p.errorf("<break time> deve estar entre 0 e %s", MaxBreak)
+		}
+		return d, nil
+	}
+
+	strength := attr(el, "strength")
+	if strength == "" {
+		strength = "medium"
+	}
+	d, ok := breakStrengths[strength]
+	if !ok {
+		return 0, p.errorf("valor inválido em <break strength=%q>", strength)
+	}
+	return d, nil
+}
+
+// rate interpreta <prosody rate>: nomes (slow, fast, ...), porcentagens
+// absolutas (150%) ou relativas (+20%) e multiplicadores (1.2)
+func (p *parser) rate(value string) (float64, error) {
+	if value == "" {
+		return 1, nil
+	}
+	if rate, ok := namedRates[value]; ok {
+		return rate, nil
+	}
+
+	var rate float64
+	var err error
+	switch {
+	case strings.HasSuffix(value, "%"):
+		number := strings.TrimSuffix(value, "%")
+		rate, err = strconv.ParseFloat(number, 64)
+		rate /= 100
+		if strings.HasPrefix(number, "+") || strings.HasPrefix(number, "-") {
+			rate += 1
+		}
+	default:
+		rate, err = strconv.ParseFloat(value, 64)
+	}
+	if err != nil || rate <= 0 {
+		return 0, p.errorf("valor inválido em <prosody rate=%q>", value)
+	}
+	return rate, nil
+}
+
+func (p *parser) sayAs(interpretAs, format, content string) (string, error) {
+	switch interpretAs {
+	case "characters", "spell-out":
+		return spell(content, func(r rune) bool { return !unicode.IsSpace(r) }), nil
+	case "digits":
+		return spell(content, unicode.IsDigit), nil
+	case "telephone":
+		return telephone(content), nil
+	case "date":
+		spoken, err := date(content, format, p.language)
+		if err != nil {
+			return "", p.errorf("%v", err)
+		}
+		return spoken, nil
+	case "":
+		return "", p.errorf("<say-as> exige o atributo interpret-as")
+	default:
+		// Outros tipos são lidos como texto normal
+		return content, nil
+	}
+}
+
+// flushText transforma o texto acumulado em segmentos, um por frase
+func (p *parser) flushText() {
+	content := strings.Join(strings.Fields(p.buf.String()), " ")
+	p.buf.Reset()
+
+	for _, sentence := range text.SplitSentences(content) {
+		p.segments = append(p.segments, Segment{
+			Text:  sentence,
+			Voice: p.voices[len(p.voices)-1],
+			Rate:  p.currentRate(),
+		})
+	}
+}
+
+func (p *parser) addSilence(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	// Pausas seguidas são somadas
+	if n := len(p.segments); n > 0 && p.segments[n-1].Silence > 0 {
+		p.segments[n-1].Silence += d
+		return
+	}
+	p.segments = append(p.segments, Segment{Silence: d})
+}
+
+func (p *parser) currentRate() float64 {
+	return p.rates[len(p.rates)-1]
+}
+
+func (p *parser) next() (xml.Token, error) {
+	tok, err := p.decoder.Token()
+	if err != nil && err != io.EOF {
+		return nil, p.syntaxError(err)
+	}
+	return tok, err
+}
+
+func (p *parser) syntaxError(err error) error {
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return p.errorf("%s", syntaxErr.Msg)
+	}
+	return p.errorf("%v", err)
+}
+
+// errorf cria um ParseError na posição atual do decoder
+func (p *parser) errorf(format string, args ...interface{}) error {
+	offset := p.decoder.InputOffset()
+	line, column := position(p.input, offset)
+	return &ParseError{Line: line, Column: column, Offset: offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+// position converte um deslocamento em bytes para linha e coluna (em
+// caracteres), ambas a partir de 1
+func position(input string, offset int64) (line, column int) {
+	if offset > int64(len(input)) {
+		offset = int64(len(input))
+	}
+	before := input[:offset]
+	line = strings.Count(before, "\n") + 1
+	lineStart := strings.LastIndex(before, "\n") + 1
+	column = len([]rune(before[lineStart:])) + 1
+	return line, column
+}
+
+func attr(el xml.StartElement, name string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == name {
+			return strings.TrimSpace(a.Value)
+		}
+	}
+	return ""
+}
+
+// parseTime interpreta durações SSML como "500ms", "1.5s" ou "2"
+func parseTime(value string) (time.Duration, error) {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		value += "s"
+	} else if !strings.HasSuffix(value, "s") {
+		return 0, fmt.Errorf("unidade inválida em %q", value)
+	}
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,206 @@
+package ssml
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"tts-api/internal/audio"
+	"tts-api/internal/audio/wav"
+	"tts-api/internal/text"
+	"tts-api/internal/timing"
+	"tts-api/internal/voice"
+)
+
+// defaultSampleRate é usada em pausas quando a voz não informa a taxa
+const defaultSampleRate = 22050
+
+// Synthesizer é implementado por voice.Manager
+type Synthesizer interface {
+	Synthesize(ctx context.Context, req voice.Request) ([]byte, error)
+	Voice(name string) (voice.VoiceInfo, bool)
+	// Parallelism retorna quantos trechos da voz podem ser sintetizados ao
+	// mesmo tempo
+	Parallelism(voice string) int
+}
+
+// FromText divide um texto simples em segmentos, um por frase
+func FromText(s string) []Segment {
+	var segments []Segment
+	for _, sentence := range text.SplitSentences(s) {
+		segments = append(segments, Segment{Text: sentence, Rate: 1})
+	}
+	return segments
+}
+
+// Voices retorna as vozes trocadas via <voice>, para validação prévia
+func Voices(segments []Segment) []string {
+	seen := make(map[string]bool)
+	var voices []string
+	for _, seg := range segments {
+		if seg.Voice != "" && !seen[seg.Voice] {
+			seen[seg.Voice] = true
+			voices = append(voices, seg.Voice)
+		}
+	}
+	return voices
+}
+
+// SampleRate retorna a taxa da voz da requisição, usada nas pausas que
+// antecedem a primeira fala
+func SampleRate(synth Synthesizer, base voice.Request) int {
+	if info, ok := synth.Voice(base.Voice); ok && info.SampleRate > 0 {
+		return info.SampleRate
+	}
+	return defaultSampleRate
+}
+
+// RenderSegment sintetiza um segmento e retorna o WAV gerado. Pausas são
+// geradas como silêncio na taxa informada.
+func RenderSegment(ctx context.Context, synth Synthesizer, base voice.Request, seg Segment, sampleRate int) ([]byte, error) {
+	if seg.Silence > 0 {
+		frames := int(seg.Silence.Seconds() * float64(sampleRate))
+		return wav.Encode(&wav.PCM{SampleRate: sampleRate, Channels: 1, Samples: make([]int16, frames)}), nil
+	}
+
+	req := base
+	req.Text = seg.Text
+	if seg.Voice != "" && seg.Voice != base.Voice {
+		req.Voice = seg.Voice
+		req.Speaker = "" // o locutor pertence à voz da requisição
+	}
+
+	if seg.Rate > 0 && seg.Rate != 1 {
+		info, ok := synth.Voice(req.Voice)
+		if !ok {
+			return nil, fmt.Errorf("voz %s não encontrada", req.Voice)
+		}
+		lengthScale := *info.Defaults.LengthScale
+		if base.Options.LengthScale != nil {
+			lengthScale = *base.Options.LengthScale
+		}
+		lengthScale = clamp(lengthScale/seg.Rate, voice.LengthScaleRange)
+		req.Options.LengthScale = &lengthScale
+	}
+
+	return synth.Synthesize(ctx, req)
+}
+
+// RenderEach sintetiza os segmentos em paralelo, até Parallelism da voz ao
+// mesmo tempo, e entrega o WAV de cada um a yield na ordem original. Os
+// segmentos prontos e ainda não entregues também ocupam a vez de uma
+// síntese, o que limita a memória quando yield é lento. A falha de um
+// segmento indica o número do trecho e cancela os seguintes.
+func RenderEach(ctx context.Context, synth Synthesizer, base voice.Request, segments []Segment, yield func(i int, data []byte) error) error {
+	type result struct {
+		data []byte
+		err  error
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	sampleRate := SampleRate(synth, base)
+	results := make([]chan result, len(segments))
+	for i := range results {
+		results[i] = make(chan result, 1)
+	}
+	slots := make(chan struct{}, max(synth.Parallelism(base.Voice), 1))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i, seg := range segments {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			wg.Add(1)
+			go func(i int, seg Segment) {
+				defer wg.Done()
+				data, err := RenderSegment(ctx, synth, base, seg, sampleRate)
+				results[i] <- result{data, err}
+			}(i, seg)
+		}
+	}()
+
+	for i, seg := range segments {
+		var r result
+		select {
+		case r = <-results[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		<-slots
+		if r.err != nil {
+			return fmt.Errorf("trecho %d de %d (%q): %w", i+1, len(segments), text.Preview(seg.Text), r.err)
+		}
+		if err := yield(i, r.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render sintetiza os segmentos em paralelo e concatena o resultado em um
+// único WAV, na taxa do primeiro segmento
+func Render(ctx context.Context, synth Synthesizer, base voice.Request, segments []Segment) ([]byte, error) {
+	data, _, err := RenderTimed(ctx, synth, base, segments)
+	return data, err
+}
+
+// RenderTimed funciona como Render e também retorna o intervalo em que cada
+// segmento com texto é falado. Pausas e o silêncio entre frases avançam o
+// tempo sem gerar entradas.
+func RenderTimed(ctx context.Context, synth Synthesizer, base voice.Request, segments []Segment) ([]byte, []timing.Sentence, error) {
+	var combined *wav.PCM
+	var sentences []timing.Sentence
+	var elapsed float64
+
+	err := RenderEach(ctx, synth, base, segments, func(i int, data []byte) error {
+		pcm, err := wav.Decode(data)
+		if err != nil {
+			return fmt.Errorf("trecho %d de %d: %w", i+1, len(segments), err)
+		}
+		pcm = pcm.Mono()
+
+		if seg := segments[i]; seg.Silence == 0 {
+			if start, end := timing.Speech(pcm); end > start {
+				sentences = append(sentences, timing.NewSentence(seg.Text, elapsed+start, elapsed+end))
+			}
+		}
+		elapsed += pcm.Duration()
+
+		if combined == nil {
+			combined = pcm
+			return nil
+		}
+		if pcm.SampleRate != combined.SampleRate {
+			pcm = audio.Resample(pcm, combined.SampleRate)
+		}
+		combined.Samples = append(combined.Samples, pcm.Samples...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if combined == nil {
+		return nil, nil, fmt.Errorf("o documento SSML não contém texto")
+	}
+
+	return wav.Encode(combined), sentences, nil
+}
+
+func clamp(v float64, r voice.Range) float64 {
+	if v < r.Min {
+		return r.Min
+	}
+	if v > r.Max {
+		return r.Max
+	}
+	return v
+}
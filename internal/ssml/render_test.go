@@ -0,0 +1,98 @@
+package ssml
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"tts-api/internal/audio/wav"
+	"tts-api/internal/voice"
+)
+
+// stubSynth devolve um WAV com uma amostra por caractere, demorando mais
+// nas frases curtas para que terminem fora de ordem
+type stubSynth struct {
+	parallelism int
+	active      atomic.Int32
+	peak        atomic.Int32
+}
+
+func (s *stubSynth) Synthesize(ctx context.Context, req voice.Request) ([]byte, error) {
+	n := s.active.Add(1)
+	defer s.active.Add(-1)
+	for {
+		peak := s.peak.Load()
+		if n <= peak || s.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	if strings.Contains(req.Text, "falha") {
+		return nil, errors.New("falha simulada")
+	}
+	select {
+	case <-time.After(time.Duration(20-len(req.Text)) * time.Millisecond):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return wav.Encode(&wav.PCM{SampleRate: 22050, Channels: 1, Samples: make([]int16, len(req.Text))}), nil
+}
+
+func (s *stubSynth) Voice(string) (voice.VoiceInfo, bool) {
+	return voice.VoiceInfo{SampleRate: 22050}, true
+}
+
+func (s *stubSynth) Parallelism(string) int {
+	return s.parallelism
+}
+
+func TestRenderEachOrder(t *testing.T) {
+	synth := &stubSynth{parallelism: 3}
+	segments := FromText("Um. Dois dois. Três três três. Quatro quatro. Cinco.")
+
+	var got []int
+	err := RenderEach(context.Background(), synth, voice.Request{Voice: "stub"}, segments, func(i int, data []byte) error {
+		pcm, err := wav.Decode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pcm.Samples) != len(segments[i].Text) {
+			t.Errorf("trecho %d com %d amostras, esperado %d", i, len(pcm.Samples), len(segments[i].Text))
+		}
+		got = append(got, i)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(segments) {
+		t.Fatalf("entregues %v, esperado %d trechos", got, len(segments))
+	}
+	for i := range got {
+		if got[i] != i {
+			t.Fatalf("ordem de entrega %v", got)
+		}
+	}
+	if peak := synth.peak.Load(); peak < 2 || peak > 3 {
+		t.Errorf("%d sínteses simultâneas, esperado entre 2 e 3", peak)
+	}
+}
+
+func TestRenderEachError(t *testing.T) {
+	synth := &stubSynth{parallelism: 2}
+	segments := FromText("Primeira frase. Esta falha. Terceira frase.")
+
+	var delivered int
+	err := RenderEach(context.Background(), synth, voice.Request{Voice: "stub"}, segments, func(int, []byte) error {
+		delivered++
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "trecho 2 de 3") || !strings.Contains(err.Error(), "Esta falha") {
+		t.Fatalf("erro %v, esperado a indicação do trecho 2 de 3", err)
+	}
+	if delivered != 1 {
+		t.Errorf("%d trechos entregues antes da falha, esperado 1", delivered)
+	}
+}
@@ -0,0 +1,109 @@
+package ssml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var monthsPT = []string{"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+	"julho", "agosto", "setembro", "outubro", "novembro", "dezembro"}
+
+var monthsEN = []string{"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+// spell separa os caracteres selecionados por keep com espaços, para que
+// sejam lidos um a um
+func spell(content string, keep func(rune) bool) string {
+	var parts []string
+	for _, r := range content {
+		if keep(r) {
+			parts = append(parts, string(r))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// telephone lê os dígitos um a um, com uma pausa curta entre os grupos
+func telephone(content string) string {
+	var groups []string
+	for _, group := range strings.FieldsFunc(content, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '+'
+	}) {
+		groups = append(groups, spell(group, func(r rune) bool { return true }))
+	}
+	return strings.Join(groups, ", ")
+}
+
+// date escreve a data por extenso. format segue o atributo de <say-as>
+// (dmy, mdy, ymd, dm, md, my, ym, d, m, y); quando omitido, datas ISO usam
+// ymd e as demais a ordem usual do idioma.
+func date(content, format, language string) (string, error) {
+	fields := strings.FieldsFunc(content, func(r rune) bool { return r == '-' || r == '/' || r == '.' })
+	portuguese := !strings.HasPrefix(strings.ToLower(language), "en")
+
+	if format == "" {
+		switch {
+		case len(fields) == 3 && len(fields[0]) == 4:
+			format = "ymd"
+		case portuguese:
+			format = "dmy"[:min(len(fields), 3)]
+		default:
+			format = "mdy"[:min(len(fields), 3)]
+		}
+	}
+	if len(fields) != len(format) {
+		return "", fmt.Errorf("data %q não corresponde ao formato %q", content, format)
+	}
+
+	var day, month, year int
+	for i, f := range format {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return "", fmt.Errorf("data inválida: %q", content)
+		}
+		switch f {
+		case 'd':
+			day = n
+		case 'm':
+			month = n
+		case 'y':
+			year = n
+		default:
+			return "", fmt.Errorf("formato de data inválido: %q", format)
+		}
+	}
+	if (day != 0 && (day < 1 || day > 31)) || (strings.ContainsRune(format, 'm') && (month < 1 || month > 12)) {
+		return "", fmt.Errorf("data inválida: %q", content)
+	}
+
+	var parts []string
+	if portuguese {
+		if day != 0 {
+			parts = append(parts, strconv.Itoa(day))
+		}
+		if month != 0 {
+			parts = append(parts, monthsPT[month-1])
+		}
+		if strings.ContainsRune(format, 'y') {
+			parts = append(parts, strconv.Itoa(year))
+		}
+		return strings.Join(parts, " de "), nil
+	}
+
+	if month != 0 {
+		parts = append(parts, monthsEN[month-1])
+	}
+	if day != 0 {
+		parts = append(parts, strconv.Itoa(day))
+	}
+	spoken := strings.Join(parts, " ")
+	if strings.ContainsRune(format, 'y') {
+		if day != 0 {
+			spoken += ","
+		}
+		spoken = strings.TrimSpace(spoken + " " + strconv.Itoa(year))
+	}
+	return spoken, nil
+}
@@ -0,0 +1,87 @@
+package text
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// paragraphBreak separa parágrafos: uma ou mais linhas em branco
+var paragraphBreak = regexp.MustCompile(`\n\s*\n`)
+
+// Chunk agrupa as frases do texto em trechos de até maxChars caracteres,
+// sem quebrar frases. Um trecho termina mais cedo ao fim de um parágrafo se
+// já tiver metade do tamanho máximo. Frases maiores que maxChars são
+// divididas no último espaço antes do limite.
+func Chunk(s string, maxChars int) []string {
+	if maxChars <= 0 || len([]rune(s)) <= maxChars {
+		if s = strings.TrimSpace(s); s == "" {
+			return nil
+		}
+		return []string{s}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	size := 0
+	flush := func() {
+		if chunk := strings.TrimSpace(current.String()); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		current.Reset()
+		size = 0
+	}
+
+	for _, paragraph := range paragraphBreak.Split(s, -1) {
+		for _, sentence := range SplitSentences(paragraph) {
+			for _, piece := range splitLong(sentence, maxChars) {
+				n := len([]rune(piece))
+				if size > 0 && size+1+n > maxChars {
+					flush()
+				}
+				if size > 0 {
+					current.WriteByte(' ')
+					size++
+				}
+				current.WriteString(piece)
+				size += n
+			}
+		}
+		if size >= maxChars/2 {
+			flush()
+		}
+	}
+	flush()
+	return chunks
+}
+
+// splitLong divide uma frase maior que maxChars em espaços
+func splitLong(sentence string, maxChars int) []string {
+	runes := []rune(sentence)
+	var pieces []string
+	for len(runes) > maxChars {
+		cut := maxChars
+		for i := maxChars; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+		pieces = append(pieces, strings.TrimSpace(string(runes[:cut])))
+		runes = []rune(strings.TrimSpace(string(runes[cut:])))
+	}
+	if len(runes) > 0 {
+		pieces = append(pieces, string(runes))
+	}
+	return pieces
+}
+
+// Preview retorna o início do trecho, usado nas mensagens de erro
+func Preview(chunk string) string {
+	const size = 40
+	runes := []rune(chunk)
+	if len(runes) <= size {
+		return chunk
+	}
+	return string(runes[:size]) + "…"
+}
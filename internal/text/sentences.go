@@ -0,0 +1,88 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// abbreviations são abreviações comuns que terminam com ponto mas não
+// encerram a frase
+var abbreviations = map[string]bool{
+	"sr": true, "sra": true, "srta": true, "dr": true, "dra": true,
+	"prof": true, "profa": true, "eng": true, "av": true, "pág": true,
+	"mr": true, "mrs": true, "ms": true, "vs": true, "st": true,
+}
+
+// SplitSentences divide o texto em frases. Uma frase termina em pontuação
+// final (. ! ? …) seguida de espaço, ou em uma quebra de linha.
+func SplitSentences(s string) []string {
+	sentences, rest := SplitComplete(s)
+	if rest = strings.TrimSpace(rest); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// SplitComplete retorna as frases completas do texto e o trecho final que
+// ainda não foi encerrado. Útil quando o texto chega em partes.
+func SplitComplete(s string) (sentences []string, rest string) {
+	runes := []rune(s)
+	start := 0
+
+	emit := func(end int) {
+		if sentence := strings.TrimSpace(string(runes[start:end])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\n' {
+			emit(i + 1)
+			continue
+		}
+		if !isTerminal(r) {
+			continue
+		}
+
+		// Inclui pontuações repetidas e aspas ou parênteses de fechamento
+		end := i + 1
+		for end < len(runes) && (isTerminal(runes[end]) || isClosing(runes[end])) {
+			end++
+		}
+		if end == len(runes) || !unicode.IsSpace(runes[end]) {
+			i = end - 1
+			continue
+		}
+		if r == '.' && isAbbreviation(runes[start:i]) {
+			i = end - 1
+			continue
+		}
+		emit(end)
+		i = end - 1
+	}
+
+	return sentences, string(runes[start:])
+}
+
+func isTerminal(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == '…'
+}
+
+func isClosing(r rune) bool {
+	return r == '"' || r == '\'' || r == ')' || r == '”' || r == '’' || r == '»'
+}
+
+// isAbbreviation verifica se a última palavra antes do ponto é uma abreviação
+func isAbbreviation(before []rune) bool {
+	word := before
+	for i := len(before) - 1; i >= 0; i-- {
+		if unicode.IsSpace(before[i]) || before[i] == '(' {
+			word = before[i+1:]
+			break
+		}
+	}
+	w := strings.ToLower(string(word))
+	return abbreviations[w] || (len(word) == 1 && unicode.IsUpper(word[0]))
+}
@@ -0,0 +1,39 @@
+package timing
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteSRT escreve as frases como legendas SubRip, uma por frase
+func WriteSRT(w io.Writer, sentences []Sentence) error {
+	var b strings.Builder
+	for i, s := range sentences {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i+1, timestamp(s.Start, ','), timestamp(s.End, ','), cueText(s.Text))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteVTT escreve as frases como legendas WebVTT, uma por frase
+func WriteVTT(w io.Writer, sentences []Sentence) error {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	for _, s := range sentences {
+		fmt.Fprintf(&b, "%s --> %s\n%s\n\n", timestamp(s.Start, '.'), timestamp(s.End, '.'), cueText(s.Text))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// timestamp formata segundos como HH:MM:SS,mmm (SRT) ou HH:MM:SS.mmm (VTT)
+func timestamp(seconds float64, separator byte) string {
+	ms := int64(seconds*1000 + 0.5)
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", ms/3600000, ms/60000%60, ms/1000%60, separator, ms%1000)
+}
+
+// cueText mantém a legenda em uma linha; linhas em branco encerrariam a cue
+func cueText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
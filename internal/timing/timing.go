@@ -0,0 +1,99 @@
+package timing
+
+import (
+	"math"
+	"strings"
+	"tts-api/internal/audio/wav"
+	"unicode"
+)
+
+// silenceThreshold é a amplitude abaixo da qual uma amostra é considerada
+// silêncio ao procurar o início e o fim da fala
+const silenceThreshold = 100
+
+// Sentence é o intervalo, em segundos desde o início do áudio, em que uma
+// frase é falada, sem o silêncio que a antecede ou sucede
+type Sentence struct {
+	Text  string  `json:"text"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Words []Word  `json:"words,omitempty"`
+}
+
+// Word é o intervalo de uma palavra dentro da frase
+type Word struct {
+	Text  string  `json:"text"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+
+	// Indica que os tempos foram estimados pela quantidade de letras, e não
+	// medidos no áudio
+	Estimated bool `json:"estimated" example:"true"`
+}
+
+// Speech retorna o início e o fim da fala no áudio, em segundos, ignorando
+// o silêncio nas pontas. Áudio sem fala retorna 0, 0.
+func Speech(pcm *wav.PCM) (start, end float64) {
+	pcm = pcm.Mono()
+	first, last := -1, -1
+	for i, s := range pcm.Samples {
+		if s > silenceThreshold || s < -silenceThreshold {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 || pcm.SampleRate == 0 {
+		return 0, 0
+	}
+	rate := float64(pcm.SampleRate)
+	return float64(first) / rate, float64(last+1) / rate
+}
+
+// NewSentence cria a frase falada entre start e end, com as palavras
+// distribuídas no intervalo. Os tempos são arredondados em milissegundos.
+func NewSentence(text string, start, end float64) Sentence {
+	words := Words(text, start, end)
+	for i := range words {
+		words[i].Start, words[i].End = round(words[i].Start), round(words[i].End)
+	}
+	return Sentence{Text: text, Start: round(start), End: round(end), Words: words}
+}
+
+func round(seconds float64) float64 {
+	return math.Round(seconds*1000) / 1000
+}
+
+// Words divide o intervalo da frase entre as palavras, proporcionalmente à
+// quantidade de letras e dígitos de cada uma. O piper não informa a duração
+// dos fonemas, então os tempos são uma estimativa.
+func Words(text string, start, end float64) []Word {
+	fields := strings.Fields(text)
+	weights := make([]int, len(fields))
+	total := 0
+	for i, field := range fields {
+		for _, r := range field {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				weights[i]++
+			}
+		}
+		if weights[i] == 0 {
+			weights[i] = 1
+		}
+		total += weights[i]
+	}
+
+	words := make([]Word, len(fields))
+	elapsed := 0
+	for i, field := range fields {
+		words[i] = Word{
+			Text:      field,
+			Start:     start + (end-start)*float64(elapsed)/float64(total),
+			Estimated: true,
+		}
+		elapsed += weights[i]
+		words[i].End = start + (end-start)*float64(elapsed)/float64(total)
+	}
+	return words
+}
@@ -0,0 +1,82 @@
+package voice
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"tts-api/internal/audio/wav"
+	"tts-api/internal/text"
+)
+
+// synthesizeChunks sintetiza os trechos em paralelo e os concatena na ordem
+// original. O piper acrescenta o silêncio entre frases também ao fim de
+// cada trecho, então a junção mantém o mesmo espaçamento da síntese única.
+// A falha de um trecho cancela os demais.
+func (m *Manager) synthesizeChunks(ctx context.Context, info VoiceInfo, req Request, chunks []string) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*wav.PCM, len(chunks))
+	var (
+		failOnce sync.Once
+		failErr  error
+	)
+	fail := func(i int, err error) {
+		failOnce.Do(func() {
+			failErr = fmt.Errorf("trecho %d de %d (%q): %w", i+1, len(chunks), text.Preview(chunks[i]), err)
+			cancel()
+		})
+	}
+	sem := make(chan struct{}, m.Parallelism(req.Voice))
+	var wg sync.WaitGroup
+
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				fail(i, ctx.Err())
+				return
+			}
+
+			chunkReq := req
+			chunkReq.Text = chunk
+			data, err := m.synthesize(ctx, info, chunkReq)
+			if err == nil {
+				results[i], err = wav.Decode(data)
+			}
+			if err != nil {
+				fail(i, err)
+			}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	// Apenas a primeira falha é informada; as seguintes decorrem do
+	// cancelamento
+	if failErr != nil {
+		return nil, failErr
+	}
+
+	combined := results[0].Mono()
+	for i, pcm := range results[1:] {
+		pcm = pcm.Mono()
+		if pcm.SampleRate != combined.SampleRate {
+			return nil, fmt.Errorf("trecho %d de %d: taxa de amostragem %d difere de %d", i+2, len(chunks), pcm.SampleRate, combined.SampleRate)
+		}
+		combined.Samples = append(combined.Samples, pcm.Samples...)
+	}
+	return wav.Encode(combined), nil
+}
+
+// Parallelism retorna quantos trechos da voz são sintetizados ao mesmo
+// tempo: SYNTH_PARALLELISM ou, na falta dele, o tamanho do pool da voz
+func (m *Manager) Parallelism(voice string) int {
+	if m.Config.SynthParallelism > 0 {
+		return m.Config.SynthParallelism
+	}
+	return max(m.Config.VoicePoolSize(voice), 1)
+}
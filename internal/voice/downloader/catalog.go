@@ -0,0 +1,43 @@
+package downloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Catalog mantém em memória o manifesto de vozes, baixado novamente após ttl
+type Catalog struct {
+	source Source
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	manifest  VoicesManifest
+	fetchedAt time.Time
+}
+
+func NewCatalog(src Source, ttl time.Duration) *Catalog {
+	return &Catalog{source: src, ttl: ttl}
+}
+
+// Source retorna a origem das vozes do catálogo
+func (c *Catalog) Source() Source {
+	return c.source
+}
+
+// Manifest retorna o manifesto em memória ou, se expirado ou com refresh,
+// o baixa novamente
+func (c *Catalog) Manifest(ctx context.Context, refresh bool) (VoicesManifest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.manifest != nil && !refresh && time.Since(c.fetchedAt) < c.ttl {
+		return c.manifest, nil
+	}
+	manifest, err := c.source.FetchManifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.manifest, c.fetchedAt = manifest, time.Now()
+	return manifest, nil
+}
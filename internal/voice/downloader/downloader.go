@@ -1,20 +1,30 @@
 package downloader
 
 import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 )
 
-const (
-	voicesJsonURL   = "https://huggingface.co/rhasspy/piper-voices/raw/main/voices.json"
-	baseDownloadURL = "https://huggingface.co/rhasspy/piper-voices/resolve/main/"
-)
+// ErrManifest indica que o manifesto de vozes não pôde ser obtido
+var ErrManifest = errors.New("erro ao buscar manifesto de vozes")
+
+// Source indica onde buscar o manifesto e os arquivos das vozes
+type Source struct {
+	ManifestURL string
+	BaseURL     string // Prefixo dos caminhos listados em VoiceInfo.Files
+}
 
 type LanguageInfo struct {
 	Code           string `json:"code"`
@@ -38,81 +48,264 @@ type VoiceInfo struct {
 	Files    map[string]FileInfo `json:"files"`
 }
 
+// Size retorna o total de bytes dos arquivos da voz
+func (v VoiceInfo) Size() int64 {
+	var total int64
+	for _, file := range v.Files {
+		total += file.SizeBytes
+	}
+	return total
+}
+
 type VoicesManifest map[string]VoiceInfo
 
-func DownloadVoices(voicesDir string, requestedVoices []string) error {
+// ByName retorna a voz com o nome informado; havendo mais de uma
+// qualidade, a primeira chave em ordem alfabética
+func (m VoicesManifest) ByName(name string) (VoiceInfo, bool) {
+	for _, key := range m.keys() {
+		if m[key].Name == name {
+			return m[key], true
+		}
+	}
+	return VoiceInfo{}, false
+}
+
+// Search filtra as vozes pelo idioma (código como pt_BR ou família como
+// pt), pela qualidade e por um texto buscado na chave, no nome e no idioma
+func (m VoicesManifest) Search(query, language, quality string) []VoiceInfo {
+	query = strings.ToLower(query)
+	voices := make([]VoiceInfo, 0)
+	for _, key := range m.keys() {
+		v := m[key]
+		if language != "" && !strings.EqualFold(v.Language.Code, language) && !strings.EqualFold(v.Language.Family, language) {
+			continue
+		}
+		if quality != "" && !strings.EqualFold(v.Quality, quality) {
+			continue
+		}
+		if query != "" {
+			haystack := strings.ToLower(strings.Join([]string{
+				v.Key, v.Name, v.Language.NameNative, v.Language.NameEnglish, v.Language.CountryEnglish,
+			}, " "))
+			if !strings.Contains(haystack, query) {
+				continue
+			}
+		}
+		voices = append(voices, v)
+	}
+	return voices
+}
+
+func (m VoicesManifest) keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Progress recebe os bytes já baixados e o total esperado
+type Progress func(done, total int64)
+
+func DownloadVoices(src Source, voicesDir string, requestedVoices []string) error {
 	if err := os.MkdirAll(voicesDir, 0755); err != nil {
 		return fmt.Errorf("falha ao criar diretório de vozes: %v", err)
 	}
 
-	manifest, err := fetchVoicesManifest()
+	manifest, err := src.FetchManifest(context.Background())
 	if err != nil {
 		return err
 	}
 
 	for _, voiceName := range requestedVoices {
 		voiceName = strings.TrimSpace(voiceName)
-		found := false
-
-		for _, voice := range manifest {
-			if voice.Name == voiceName {
-				found = true
-				if err := downloadVoiceFiles(voice, voicesDir); err != nil {
-					log.Printf("Erro ao baixar voz %s: %v", voiceName, err)
-					continue
-				}
-				break
-			}
+		if voiceName == "" {
+			continue
 		}
 
+		voice, found := manifest.ByName(voiceName)
 		if !found {
 			log.Printf("Aviso: voz %s não encontrada no manifesto", voiceName)
+			continue
+		}
+		if ok, _ := UpToDate(voicesDir, voice); ok {
+			continue
+		}
+		if err := Install(context.Background(), src, voicesDir, voice, nil); err != nil {
+			log.Printf("Erro ao baixar voz %s: %v", voiceName, err)
 		}
 	}
 
 	return nil
 }
 
-func fetchVoicesManifest() (VoicesManifest, error) {
-	resp, err := http.Get(voicesJsonURL)
+// FetchManifest baixa o manifesto de vozes
+func (s Source) FetchManifest(ctx context.Context) (VoicesManifest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.ManifestURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao buscar manifesto de vozes: %v", err)
+		return nil, fmt.Errorf("%w: %v", ErrManifest, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrManifest, err)
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrManifest, resp.StatusCode)
+	}
 
 	var manifest VoicesManifest
 	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
-		return nil, fmt.Errorf("erro ao decodificar manifesto: %v", err)
+		return nil, fmt.Errorf("%w: erro ao decodificar: %v", ErrManifest, err)
 	}
 
 	return manifest, nil
 }
 
-func downloadVoiceFiles(voice VoiceInfo, voicesDir string) error {
-	voiceDir := filepath.Join(voicesDir, voice.Name)
-	if err := os.MkdirAll(voiceDir, 0755); err != nil {
+// Install baixa os arquivos da voz para um diretório oculto em voicesDir,
+// confere o tamanho e o MD5 de cada um e só então o move para
+// voicesDir/<nome>, substituindo a versão anterior. Os arquivos da versão
+// anterior que não vêm do catálogo, como os léxicos, são mantidos.
+func Install(ctx context.Context, src Source, voicesDir string, voice VoiceInfo, progress Progress) error {
+	tmpDir, err := os.MkdirTemp(voicesDir, ".install-"+voice.Name+"-")
+	if err != nil {
 		return fmt.Errorf("erro ao criar diretório para a voz %s: %v", voice.Name, err)
 	}
+	defer os.RemoveAll(tmpDir)
+
+	total := voice.Size()
+	var done int64
+	report := func(n int64) {
+		done += n
+		if progress != nil {
+			progress(done, total)
+		}
+	}
 
+	files := make([]string, 0, len(voice.Files))
 	for filePath := range voice.Files {
-		url := baseDownloadURL + filePath
+		files = append(files, filePath)
+	}
+	sort.Strings(files)
+
+	for _, filePath := range files {
 		filename := filepath.Base(filePath)
-		targetPath := filepath.Join(voiceDir, filename)
+		targetPath := filepath.Join(tmpDir, filename)
+		if err := downloadFile(ctx, src.BaseURL+filePath, targetPath, voice.Files[filePath], report); err != nil {
+			return fmt.Errorf("erro ao baixar %s: %v", filename, err)
+		}
+		log.Printf("Arquivo baixado com sucesso: %s", filename)
+	}
 
-		if err := downloadFile(url, targetPath); err != nil {
+	if err := os.Chmod(tmpDir, 0755); err != nil {
+		return err
+	}
+	dest := filepath.Join(voicesDir, voice.Name)
+	if err := preserveFiles(dest, tmpDir); err != nil {
+		return fmt.Errorf("erro ao manter os arquivos locais da voz %s: %v", voice.Name, err)
+	}
+	return replaceDir(tmpDir, dest)
+}
+
+// preserveFiles copia de oldDir para newDir os arquivos que não existem em
+// newDir, exceto modelos, para que uma atualização não descarte os léxicos
+// e demais arquivos locais da voz
+func preserveFiles(oldDir, newDir string) error {
+	entries, err := os.ReadDir(oldDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		// Um modelo com outro nome deixaria dois .onnx no diretório
+		if !entry.Type().IsRegular() || strings.HasSuffix(name, ".onnx") || strings.HasSuffix(name, ".onnx.json") {
+			continue
+		}
+		target := filepath.Join(newDir, name)
+		if _, err := os.Stat(target); err == nil {
+			continue
+		}
+		if err := copyFile(filepath.Join(oldDir, name), target); err != nil {
 			return err
 		}
-		log.Printf("Arquivo baixado com sucesso: %s", filename)
 	}
 	return nil
 }
 
-func downloadFile(url, targetPath string) error {
-	resp, err := http.Get(url)
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// UpToDate indica se os arquivos instalados da voz conferem com o manifesto
+func UpToDate(voicesDir string, voice VoiceInfo) (bool, error) {
+	for filePath, expected := range voice.Files {
+		path := filepath.Join(voicesDir, voice.Name, filepath.Base(filePath))
+		file, err := os.Open(path)
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		hash := md5.New()
+		size, err := io.Copy(hash, file)
+		file.Close()
+		if err != nil {
+			return false, err
+		}
+		if size != expected.SizeBytes || hex.EncodeToString(hash.Sum(nil)) != expected.MD5Digest {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// InstalledKey retorna a chave do manifesto de uma voz instalada, que o
+// piper usa como nome do modelo (<chave>.onnx)
+func InstalledKey(voiceDir string) (string, error) {
+	models, err := filepath.Glob(filepath.Join(voiceDir, "*.onnx"))
+	if err != nil || len(models) == 0 {
+		return "", fmt.Errorf("nenhum arquivo .onnx encontrado na voz %s", voiceDir)
+	}
+	return strings.TrimSuffix(filepath.Base(models[0]), ".onnx"), nil
+}
+
+func downloadFile(ctx context.Context, url, targetPath string, expected FileInfo, report func(int64)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
 
 	out, err := os.Create(targetPath)
 	if err != nil {
@@ -120,6 +313,44 @@ func downloadFile(url, targetPath string) error {
 	}
 	defer out.Close()
 
-	_, err = io.Copy(out, resp.Body)
-	return err
+	hash := md5.New()
+	size, err := io.Copy(io.MultiWriter(out, hash, progressWriter(report)), resp.Body)
+	if err != nil {
+		return err
+	}
+	if expected.SizeBytes > 0 && size != expected.SizeBytes {
+		return fmt.Errorf("tamanho %d difere do manifesto (%d)", size, expected.SizeBytes)
+	}
+	if digest := hex.EncodeToString(hash.Sum(nil)); expected.MD5Digest != "" && digest != expected.MD5Digest {
+		return fmt.Errorf("MD5 %s difere do manifesto (%s)", digest, expected.MD5Digest)
+	}
+	return out.Close()
+}
+
+// replaceDir move src para dest, descartando o conteúdo anterior de dest
+func replaceDir(src, dest string) error {
+	var old string
+	if _, err := os.Stat(dest); err == nil {
+		old = filepath.Join(filepath.Dir(dest), fmt.Sprintf(".old-%s-%d", filepath.Base(dest), time.Now().UnixNano()))
+		if err := os.Rename(dest, old); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(src, dest); err != nil {
+		if old != "" {
+			os.Rename(old, dest)
+		}
+		return err
+	}
+	if old != "" {
+		os.RemoveAll(old)
+	}
+	return nil
+}
+
+type progressWriter func(int64)
+
+func (p progressWriter) Write(b []byte) (int, error) {
+	p(int64(len(b)))
+	return len(b), nil
 }
@@ -0,0 +1,89 @@
+package downloader
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// catalogServer serve os arquivos informados e retorna a voz do manifesto
+// correspondente
+func catalogServer(t *testing.T, files map[string]string) (Source, VoiceInfo) {
+	t.Helper()
+	voice := VoiceInfo{Key: "pt_BR-teste-medium", Name: "teste", Files: make(map[string]FileInfo)}
+	for path, content := range files {
+		sum := md5.Sum([]byte(content))
+		voice.Files[path] = FileInfo{SizeBytes: int64(len(content)), MD5Digest: hex.EncodeToString(sum[:])}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path[1:]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+	return Source{BaseURL: server.URL + "/"}, voice
+}
+
+func TestInstallKeepsLexicon(t *testing.T) {
+	dir := t.TempDir()
+	const model = "pt/pt_BR/teste/medium/pt_BR-teste-medium.onnx"
+
+	src, voice := catalogServer(t, map[string]string{model: "modelo v1", model + ".json": "{}"})
+	if err := Install(context.Background(), src, dir, voice, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	voiceDir := filepath.Join(dir, "teste")
+	local := map[string]string{
+		"lexicon.json": `[{"word":"SUS","alias":"sus"}]`,
+		"lexicon.pls":  "<lexicon/>",
+		"lexicon.csv":  "word,alias,phonemes\n",
+	}
+	for name, content := range local {
+		if err := os.WriteFile(filepath.Join(voiceDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Modelo de uma chave anterior, que não deve ser copiado
+	os.WriteFile(filepath.Join(voiceDir, "antigo.onnx"), []byte("x"), 0644)
+
+	src, voice = catalogServer(t, map[string]string{model: "modelo v2", model + ".json": "{}"})
+	if ok, _ := UpToDate(dir, voice); ok {
+		t.Fatal("voz com modelo alterado considerada atualizada")
+	}
+	if err := Install(context.Background(), src, dir, voice, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(voiceDir, "pt_BR-teste-medium.onnx"))
+	if err != nil || string(data) != "modelo v2" {
+		t.Fatalf("modelo não atualizado: %q, %v", data, err)
+	}
+	for name, content := range local {
+		data, err := os.ReadFile(filepath.Join(voiceDir, name))
+		if err != nil || string(data) != content {
+			t.Errorf("%s não foi mantido: %q, %v", name, data, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(voiceDir, "antigo.onnx")); !os.IsNotExist(err) {
+		t.Errorf("modelo anterior copiado para a nova versão: %v", err)
+	}
+	if ok, err := UpToDate(dir, voice); !ok || err != nil {
+		t.Errorf("voz não confere com o manifesto após a atualização: %v", err)
+	}
+
+	// Nenhum diretório temporário ou versão anterior fica em voicesDir
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("entradas em voicesDir: %v", entries)
+	}
+}
@@ -0,0 +1,120 @@
+package voice
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"tts-api/internal/config"
+)
+
+// Engine é um backend de síntese de voz
+type Engine interface {
+	// Synthesize gera um áudio WAV para a requisição
+	Synthesize(ctx context.Context, req Request) ([]byte, error)
+	// Voices retorna os nomes das vozes disponíveis no backend
+	Voices() []string
+	// Voice retorna os detalhes de uma voz
+	Voice(name string) (VoiceInfo, bool)
+	// Close libera os recursos do backend
+	Close() error
+}
+
+// PhonemeEngine é implementado pelos engines que aceitam fonemas IPA como
+// entrada (Request.Phonemes) e expõem a fonemização usada pelas vozes
+type PhonemeEngine interface {
+	// Phonemize retorna os fonemas IPA que a voz usaria para o texto
+	Phonemize(ctx context.Context, voice, text string) (string, error)
+}
+
+// ModelVersioner é implementado pelos engines que identificam a versão do
+// modelo de uma voz, usada para detectar a troca do modelo
+type ModelVersioner interface {
+	ModelVersion(voice string) (string, error)
+}
+
+// VoiceLoader é implementado pelos engines que carregam e descarregam
+// vozes de VoicesDir em tempo de execução
+type VoiceLoader interface {
+	// LoadVoice valida e registra a voz, substituindo a versão anterior
+	LoadVoice(name string) error
+	// UnloadVoice remove a voz após o término das requisições em andamento
+	UnloadVoice(name string) error
+}
+
+// Request representa uma requisição de síntese enviada ao Engine
+type Request struct {
+	Voice   string
+	Text    string
+	Speaker string // nome ou id do locutor, para modelos multi-locutor
+	Options Options
+
+	// SkipNormalization envia o texto ao engine sem expandir números,
+	// datas e abreviações
+	SkipNormalization bool
+
+	// Phonemes indica que Text contém fonemas IPA em vez de texto
+	Phonemes bool
+
+	// SpeakerID é preenchido pelo Manager a partir de Speaker
+	SpeakerID *int
+}
+
+// VoiceInfo descreve uma voz disponível no Engine
+type VoiceInfo struct {
+	Name       string
+	SampleRate int
+	Language   string    // código do idioma (pt_BR), vazio se desconhecido
+	Defaults   Options   // prosódia padrão, com todos os campos preenchidos
+	Speakers   []Speaker // vazio para modelos com um único locutor
+}
+
+// EngineFactory cria um Engine a partir da configuração
+type EngineFactory func(cfg *config.Config) (Engine, error)
+
+var (
+	enginesMu sync.RWMutex
+	engines   = make(map[string]EngineFactory)
+)
+
+func init() {
+	RegisterEngine("piper", func(cfg *config.Config) (Engine, error) {
+		return NewPiperEngine(cfg)
+	})
+	RegisterEngine("fake", func(cfg *config.Config) (Engine, error) {
+		return NewFakeEngine(cfg.Voices...), nil
+	})
+}
+
+// RegisterEngine registra um backend que pode ser selecionado pelo nome em
+// config.Config.Engine
+func RegisterEngine(name string, factory EngineFactory) {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	engines[name] = factory
+}
+
+// Engines retorna os nomes dos backends registrados
+func Engines() []string {
+	enginesMu.RLock()
+	defer enginesMu.RUnlock()
+
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewEngine cria o backend configurado em cfg.Engine
+func NewEngine(cfg *config.Config) (Engine, error) {
+	enginesMu.RLock()
+	factory, exists := engines[cfg.Engine]
+	enginesMu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("engine %s não registrado (disponíveis: %v)", cfg.Engine, Engines())
+	}
+	return factory(cfg)
+}
@@ -0,0 +1,79 @@
+package voice
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// clausePattern separa o texto em orações seguidas da pontuação que as
+// encerra
+var clausePattern = regexp.MustCompile(`[^,.;:!?…]+[,.;:!?…]*`)
+
+// languageSwitch remove as marcações de troca de idioma do espeak, como
+// "(en)" e "(pt)"
+var languageSwitch = regexp.MustCompile(`\([a-z]{2,3}(?:-[a-z0-9]+)?\)`)
+
+// espeakPhonemize converte o texto em fonemas IPA com o espeak-ng, como o
+// piper faz antes da síntese. O espeak descarta a pontuação, que é devolvida
+// ao fim de cada oração porque o modelo também a recebe.
+func espeakPhonemize(ctx context.Context, binary, dataPath, espeakVoice, text string) (string, error) {
+	if _, err := exec.LookPath(binary); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrPhonemesUnsupported, err)
+	}
+
+	var clauses, punctuation []string
+	for _, clause := range clausePattern.FindAllString(text, -1) {
+		words := strings.TrimRight(clause, ",.;:!?…")
+		if strings.TrimSpace(words) == "" {
+			continue
+		}
+		clauses = append(clauses, strings.TrimSpace(words))
+		punctuation = append(punctuation, clause[len(words):])
+	}
+	// Apenas pontuação, como o fim de frase após um trecho [[ ]]
+	if len(clauses) == 0 {
+		return strings.TrimSpace(text), nil
+	}
+
+	args := []string{"-q", "--ipa", "-v", espeakVoice, "--stdin"}
+	if dataPath != "" {
+		args = append(args, "--path", dataPath)
+	}
+	cmd := exec.CommandContext(ctx, binary, args...)
+	// Uma oração por linha, com a pontuação, para que o espeak também
+	// quebre a saída a cada oração
+	var input strings.Builder
+	for i, clause := range clauses {
+		input.WriteString(clause + punctuation[i] + "\n")
+	}
+	cmd.Stdin = strings.NewReader(input.String())
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("erro na fonemização: %v: %s", err, stderr.String())
+	}
+
+	var lines []string
+	for _, line := range strings.Split(out.String(), "\n") {
+		line = strings.Join(strings.Fields(languageSwitch.ReplaceAllString(line, "")), " ")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	// Sem a correspondência de uma linha por oração não há como saber onde
+	// recolocar a pontuação
+	if len(lines) != len(clauses) {
+		return strings.Join(lines, " "), nil
+	}
+	for i := range lines {
+		lines[i] += strings.TrimSpace(punctuation[i])
+	}
+	return strings.Join(lines, " "), nil
+}
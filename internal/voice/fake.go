@@ -0,0 +1,106 @@
+package voice
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"tts-api/internal/audio/wav"
+	"unicode"
+)
+
+const (
+	fakeSampleRate   = 22050
+	fakeRuneDuration = 0.08 // segundos por caractere
+	fakePauseSeconds = 0.2  // segundos de silêncio após vírgulas e afins
+)
+
+// FakeEngine gera áudio determinístico sem depender do piper: cada letra ou
+// dígito vira um tom, espaços viram silêncio e pontuação vira uma pausa
+// (respeitando length_scale e sentence_silence). É útil para testes e para
+// rodar a API sem modelos instalados.
+type FakeEngine struct {
+	voices []string
+}
+
+// NewFakeEngine cria um engine falso com as vozes informadas. Sem vozes, é
+// registrada apenas a voz "fake".
+func NewFakeEngine(voices ...string) *FakeEngine {
+	e := &FakeEngine{}
+	for _, voice := range voices {
+		if voice = strings.TrimSpace(voice); voice != "" {
+			e.voices = append(e.voices, voice)
+		}
+	}
+	if len(e.voices) == 0 {
+		e.voices = []string{"fake"}
+	}
+	return e
+}
+
+func (e *FakeEngine) Synthesize(ctx context.Context, req Request) ([]byte, error) {
+	if !e.hasVoice(req.Voice) {
+		return nil, fmt.Errorf("voz %s não encontrada", req.Voice)
+	}
+
+	opts := req.Options.WithDefaults(defaultOptions)
+	runeSeconds := fakeRuneDuration * *opts.LengthScale
+
+	var samples []int16
+	for _, r := range prepareText(req.Text) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			freq := 220 + float64(unicode.ToLower(r)%24)*20
+			samples = appendTone(samples, freq, runeSeconds)
+		case strings.ContainsRune(".!?", r):
+			samples = appendTone(samples, 0, *opts.SentenceSilence)
+		case strings.ContainsRune(";:,", r):
+			samples = appendTone(samples, 0, fakePauseSeconds)
+		default:
+			samples = appendTone(samples, 0, runeSeconds)
+		}
+	}
+
+	return wav.Encode(&wav.PCM{SampleRate: fakeSampleRate, Channels: 1, Samples: samples}), nil
+}
+
+func (e *FakeEngine) Voices() []string {
+	return append([]string(nil), e.voices...)
+}
+
+func (e *FakeEngine) Voice(name string) (VoiceInfo, bool) {
+	if !e.hasVoice(name) {
+		return VoiceInfo{}, false
+	}
+	return VoiceInfo{Name: name, SampleRate: fakeSampleRate, Defaults: defaultOptions}, true
+}
+
+func (e *FakeEngine) Close() error {
+	return nil
+}
+
+func (e *FakeEngine) hasVoice(voice string) bool {
+	for _, name := range e.voices {
+		if name == voice {
+			return true
+		}
+	}
+	return false
+}
+
+// appendTone acrescenta uma senoide (ou silêncio quando freq é zero)
+func appendTone(samples []int16, freq, seconds float64) []int16 {
+	n := int(seconds * fakeSampleRate)
+	for i := 0; i < n; i++ {
+		var v float64
+		if freq > 0 {
+			v = 0.3 * math.Sin(2*math.Pi*freq*float64(i)/fakeSampleRate)
+		}
+		samples = append(samples, int16(v*math.MaxInt16))
+	}
+	return samples
+}
@@ -0,0 +1,309 @@
+package installer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+	"tts-api/internal/voice"
+	"tts-api/internal/voice/downloader"
+)
+
+var (
+	// ErrUnknownKey é retornado para chaves ausentes do manifesto
+	ErrUnknownKey = errors.New("voz não encontrada no catálogo")
+	// ErrInstalled é retornado ao instalar uma voz já existente
+	ErrInstalled = errors.New("voz já instalada")
+	// ErrNotInstalled é retornado ao atualizar ou remover uma voz ausente
+	ErrNotInstalled = errors.New("voz não instalada")
+	// ErrBusy é retornado quando já há uma tarefa em andamento para a voz
+	ErrBusy = errors.New("já existe uma tarefa em andamento para a voz")
+)
+
+// maxFinishedTasks é quantas tarefas concluídas ficam disponíveis para
+// consulta
+const maxFinishedTasks = 50
+
+// namePattern impede nomes que escapem de VoicesDir ou coincidam com os
+// diretórios ocultos das instalações em andamento
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+type Kind string
+
+const (
+	KindInstall Kind = "install"
+	KindUpdate  Kind = "update"
+)
+
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Progress indica os bytes já baixados dos arquivos da voz
+type Progress struct {
+	Bytes   int64   `json:"bytes"`
+	Total   int64   `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+// Task é uma instalação ou atualização de voz em segundo plano. Os valores
+// retornados pelo Installer são cópias.
+type Task struct {
+	ID         string     `json:"id"`
+	Kind       Kind       `json:"kind"`
+	Voice      string     `json:"voice"`
+	Key        string     `json:"key,omitempty"`
+	Status     Status     `json:"status"`
+	Progress   Progress   `json:"progress"`
+	Message    string     `json:"message,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Erro       string     `json:"erro,omitempty"`
+}
+
+// Installer baixa vozes do catálogo para VoicesDir e as registra no
+// voice.Manager sem reiniciar o serviço
+type Installer struct {
+	voices  *voice.Manager
+	catalog *downloader.Catalog
+	dir     string
+
+	ctx  context.Context
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+
+	mu     sync.Mutex
+	tasks  map[string]*Task
+	active map[string]string // voz -> id da tarefa em andamento
+}
+
+func New(vm *voice.Manager, catalog *downloader.Catalog) *Installer {
+	ctx, stop := context.WithCancel(context.Background())
+	return &Installer{
+		voices:  vm,
+		catalog: catalog,
+		dir:     vm.GetVoicesDir(),
+		ctx:     ctx,
+		stop:    stop,
+		tasks:   make(map[string]*Task),
+		active:  make(map[string]string),
+	}
+}
+
+// Install inicia a instalação da voz com a chave do manifesto
+// (pt_BR-faber-medium, por exemplo)
+func (i *Installer) Install(ctx context.Context, key string) (Task, error) {
+	manifest, err := i.catalog.Manifest(ctx, false)
+	if err != nil {
+		return Task{}, err
+	}
+	info, exists := manifest[key]
+	if !exists {
+		return Task{}, fmt.Errorf("%w: %s", ErrUnknownKey, key)
+	}
+	if !namePattern.MatchString(info.Name) {
+		return Task{}, fmt.Errorf("nome de voz inválido no catálogo: %q", info.Name)
+	}
+	if i.installed(info.Name) {
+		return Task{}, fmt.Errorf("%w: %s", ErrInstalled, info.Name)
+	}
+
+	return i.start(KindInstall, info.Name, key, func(task *Task) (string, error) {
+		return "", i.download(task, info)
+	})
+}
+
+// Update inicia a atualização da voz a partir do manifesto atual. A voz
+// não é baixada novamente se os arquivos conferirem com o manifesto.
+func (i *Installer) Update(name string) (Task, error) {
+	if !namePattern.MatchString(name) || !i.installed(name) {
+		return Task{}, fmt.Errorf("%w: %s", ErrNotInstalled, name)
+	}
+	key, err := downloader.InstalledKey(filepath.Join(i.dir, name))
+	if err != nil {
+		return Task{}, err
+	}
+
+	return i.start(KindUpdate, name, key, func(task *Task) (string, error) {
+		manifest, err := i.catalog.Manifest(i.ctx, true)
+		if err != nil {
+			return "", err
+		}
+		info, exists := manifest[key]
+		if !exists {
+			return "", fmt.Errorf("%w: %s", ErrUnknownKey, key)
+		}
+		if ok, err := downloader.UpToDate(i.dir, info); err != nil {
+			return "", err
+		} else if ok {
+			return "voz já atualizada", nil
+		}
+		return "", i.download(task, info)
+	})
+}
+
+// Remove descarrega a voz e apaga o diretório. As requisições em
+// andamento são concluídas com os arquivos já abertos.
+func (i *Installer) Remove(name string) error {
+	if !namePattern.MatchString(name) || !i.installed(name) {
+		return fmt.Errorf("%w: %s", ErrNotInstalled, name)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if _, busy := i.active[name]; busy {
+		return fmt.Errorf("%w %s", ErrBusy, name)
+	}
+
+	if i.voices.HasVoice(name) {
+		if err := i.voices.UnloadVoice(name); err != nil {
+			return err
+		}
+	}
+	if err := os.RemoveAll(filepath.Join(i.dir, name)); err != nil {
+		return fmt.Errorf("erro ao remover a voz %s: %v", name, err)
+	}
+	log.Printf("Voz %s removida", name)
+	return nil
+}
+
+// Task retorna uma tarefa pelo id
+func (i *Installer) Task(id string) (Task, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	task, exists := i.tasks[id]
+	if !exists {
+		return Task{}, false
+	}
+	return *task, true
+}
+
+// Tasks retorna as tarefas da mais recente para a mais antiga
+func (i *Installer) Tasks() []Task {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	tasks := make([]Task, 0, len(i.tasks))
+	for _, task := range i.tasks {
+		tasks = append(tasks, *task)
+	}
+	sort.Slice(tasks, func(a, b int) bool { return tasks[a].CreatedAt.After(tasks[b].CreatedAt) })
+	return tasks
+}
+
+// Close cancela os downloads em andamento
+func (i *Installer) Close() {
+	i.stop()
+	i.wg.Wait()
+}
+
+// start registra a tarefa e executa run em segundo plano. A mensagem
+// retornada por run é informada na tarefa concluída.
+func (i *Installer) start(kind Kind, name, key string, run func(*Task) (string, error)) (Task, error) {
+	id, err := newID()
+	if err != nil {
+		return Task{}, err
+	}
+
+	i.mu.Lock()
+	if _, busy := i.active[name]; busy {
+		i.mu.Unlock()
+		return Task{}, fmt.Errorf("%w %s", ErrBusy, name)
+	}
+	task := &Task{
+		ID:        id,
+		Kind:      kind,
+		Voice:     name,
+		Key:       key,
+		Status:    StatusRunning,
+		CreatedAt: time.Now().UTC(),
+	}
+	i.tasks[id] = task
+	i.active[name] = id
+	i.pruneLocked()
+	snapshot := *task
+	i.mu.Unlock()
+
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+		message, err := run(task)
+
+		i.mu.Lock()
+		defer i.mu.Unlock()
+		now := time.Now().UTC()
+		task.FinishedAt = &now
+		task.Message = message
+		delete(i.active, name)
+		if err != nil {
+			task.Status, task.Erro = StatusFailed, err.Error()
+			log.Printf("Erro na tarefa %s (%s da voz %s): %v", task.ID, kind, name, err)
+			return
+		}
+		task.Status = StatusCompleted
+	}()
+	return snapshot, nil
+}
+
+// download instala os arquivos da voz e a registra no voice.Manager
+func (i *Installer) download(task *Task, info downloader.VoiceInfo) error {
+	progress := func(done, total int64) {
+		i.mu.Lock()
+		defer i.mu.Unlock()
+		task.Progress = Progress{Bytes: done, Total: total}
+		if total > 0 {
+			task.Progress.Percent = float64(done) / float64(total) * 100
+		}
+	}
+	progress(0, info.Size())
+	if err := downloader.Install(i.ctx, i.catalog.Source(), i.dir, info, progress); err != nil {
+		return err
+	}
+	return i.voices.LoadVoice(info.Name)
+}
+
+func (i *Installer) installed(name string) bool {
+	if i.voices.HasVoice(name) {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(i.dir, name))
+	return err == nil
+}
+
+// pruneLocked descarta as tarefas concluídas mais antigas
+func (i *Installer) pruneLocked() {
+	var finished []*Task
+	for _, task := range i.tasks {
+		if task.Status != StatusRunning {
+			finished = append(finished, task)
+		}
+	}
+	if len(finished) <= maxFinishedTasks {
+		return
+	}
+	sort.Slice(finished, func(a, b int) bool { return finished[a].CreatedAt.Before(finished[b].CreatedAt) })
+	for _, task := range finished[:len(finished)-maxFinishedTasks] {
+		delete(i.tasks, task.ID)
+	}
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("erro ao gerar id da tarefa: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
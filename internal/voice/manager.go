@@ -1,96 +1,294 @@
 package voice
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"os"
-	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 	"tts-api/internal/config"
+	"tts-api/internal/lexicon"
+	"tts-api/internal/normalize"
+	"tts-api/internal/text"
 )
 
 type Manager struct {
-	voices    map[string]string // mapa de nome -> caminho do arquivo
-	voicesDir string
-	mu        sync.RWMutex
-	Config    *config.Config // Adicionado
+	engine   Engine
+	lexicons *lexicon.Store
+	Config   *config.Config // Adicionado
+
+	watcher     *voiceWatcher // nil sem monitoramento de VoicesDir
+	subsMu      sync.Mutex
+	subscribers map[chan VoiceEvent]struct{}
 }
 
+// NewManager cria o gerenciador usando o engine selecionado em cfg.Engine
 func NewManager(cfg *config.Config) (*Manager, error) {
-	voicesDir := cfg.VoicesDir
-	if err := os.MkdirAll(voicesDir, 0755); err != nil {
-		return nil, fmt.Errorf("falha ao criar diretório de vozes: %v", err)
+	engine, err := NewEngine(cfg)
+	if err != nil {
+		return nil, err
 	}
 
+	m, err := NewManagerWithEngine(cfg, engine)
+	if err != nil {
+		engine.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// NewManagerWithEngine cria o gerenciador com um engine já construído. Se o
+// engine carregar vozes em tempo de execução, VoicesDir é monitorado
+// conforme cfg.VoicesWatch.
+func NewManagerWithEngine(cfg *config.Config, engine Engine) (*Manager, error) {
 	m := &Manager{
-		voices:    make(map[string]string),
-		voicesDir: voicesDir,
-		Config:    cfg, // Atribui a configuração
+		engine:      engine,
+		lexicons:    lexicon.NewStore(cfg),
+		Config:      cfg, // Atribui a configuração
+		subscribers: make(map[chan VoiceEvent]struct{}),
 	}
 
-	entries, err := os.ReadDir(voicesDir)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao ler diretório de vozes: %v", err)
+	// Vozes podem ser instaladas depois quando o engine as carrega em
+	// tempo de execução
+	loader, reloadable := engine.(VoiceLoader)
+	if len(engine.Voices()) == 0 {
+		if !reloadable {
+			return nil, fmt.Errorf("nenhuma voz foi encontrada")
+		}
+		log.Printf("Aviso: nenhuma voz encontrada; aguardando vozes em %s", cfg.VoicesDir)
 	}
 
-	for _, entry := range entries {
-		if entry.IsDir() {
-			voiceName := entry.Name()
-			voicePath := filepath.Join(voicesDir, voiceName)
-			m.voices[voiceName] = voicePath
-			log.Printf("Voz encontrada: %s", voiceName)
+	if reloadable {
+		m.watcher = newVoiceWatcher(m, loader)
+		if cfg.VoicesWatch != "off" {
+			m.watcher.start(cfg.VoicesWatch)
 		}
 	}
+	return m, nil
+}
 
-	if len(m.voices) == 0 {
-		return nil, fmt.Errorf("nenhuma voz foi encontrada")
+// LoadVoice registra ou atualiza imediatamente a voz de VoicesDir/name, sem
+// aguardar o monitoramento do diretório
+func (m *Manager) LoadVoice(name string) error {
+	if m.watcher == nil {
+		return fmt.Errorf("o engine %s não carrega vozes em tempo de execução", m.Config.Engine)
 	}
+	return m.watcher.load(name)
+}
 
-	return m, nil
+// UnloadVoice remove imediatamente a voz; as requisições em andamento são
+// concluídas
+func (m *Manager) UnloadVoice(name string) error {
+	if m.watcher == nil {
+		return fmt.Errorf("o engine %s não carrega vozes em tempo de execução", m.Config.Engine)
+	}
+	return m.watcher.unload(name)
 }
 
-func (m *Manager) Synthesize(text, voiceName string) ([]byte, error) {
-	m.mu.RLock()
-	voiceDir, exists := m.voices[voiceName]
-	m.mu.RUnlock()
+// Subscribe retorna um canal com as mudanças de vozes em VoicesDir e a
+// função que cancela a inscrição. Eventos são descartados se o canal
+// estiver cheio.
+func (m *Manager) Subscribe() (<-chan VoiceEvent, func()) {
+	ch := make(chan VoiceEvent, 16)
+	m.subsMu.Lock()
+	m.subscribers[ch] = struct{}{}
+	m.subsMu.Unlock()
 
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			m.subsMu.Lock()
+			delete(m.subscribers, ch)
+			m.subsMu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// emit registra a mudança no log e a envia aos inscritos
+func (m *Manager) emit(event VoiceEvent) {
+	event.Time = time.Now().UTC()
+	if event.Error != "" {
+		log.Printf("Voz %s (%s): %s", event.Voice, event.Type, event.Error)
+	} else {
+		log.Printf("Voz %s (%s)", event.Voice, event.Type)
+	}
+
+	m.subsMu.Lock()
+	defer m.subsMu.Unlock()
+	for ch := range m.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Synthesize valida a requisição, preenche a prosódia com os padrões da voz,
+// aplica o léxico e a normalização do texto e encaminha para o engine
+func (m *Manager) Synthesize(ctx context.Context, req Request) ([]byte, error) {
+	info, exists := m.engine.Voice(req.Voice)
 	if !exists {
-		return nil, fmt.Errorf("voz %s não encontrada", voiceName)
+		return nil, fmt.Errorf("voz %s não encontrada", req.Voice)
 	}
 
-	if text == "" {
+	if req.Text == "" {
 		return nil, fmt.Errorf("texto não pode estar vazio")
 	}
 
-	return Synthesize(voiceDir, text)
-}
+	if err := req.Options.Validate(); err != nil {
+		return nil, err
+	}
+	req.Options = req.Options.WithDefaults(info.Defaults)
 
-func (m *Manager) ListVoices() []string {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	if req.Speaker != "" {
+		id, err := info.ResolveSpeaker(req.Speaker)
+		if err != nil {
+			return nil, err
+		}
+		req.SpeakerID = &id
+	}
 
-	voices := make([]string, 0, len(m.voices))
-	for voice := range m.voices {
-		voices = append(voices, voice)
+	// Textos longos são divididos em trechos sintetizados em paralelo
+	if !req.Phonemes {
+		if chunks := text.Chunk(req.Text, m.Config.SynthChunkSize); len(chunks) > 1 {
+			return m.synthesizeChunks(ctx, info, req, chunks)
+		}
 	}
-	return voices
+	return m.synthesize(ctx, info, req)
 }
 
-func (m *Manager) GetVoicePath(voice string) (string, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+// synthesize aplica o léxico e a normalização e encaminha a requisição já
+// validada para o engine
+func (m *Manager) synthesize(ctx context.Context, info VoiceInfo, req Request) ([]byte, error) {
+	phonemeEngine, acceptsPhonemes := m.engine.(PhonemeEngine)
+	if req.Phonemes {
+		if !acceptsPhonemes {
+			return nil, fmt.Errorf("%w pelo engine %s", ErrPhonemesUnsupported, m.Config.Engine)
+		}
+		return m.engine.Synthesize(ctx, req)
+	}
 
-	path, exists := m.voices[voice]
+	req.Text = m.PrepareText(info, req.Text, req.SkipNormalization)
+
+	// Pronúncias do léxico em fonemas exigem que todo o texto seja enviado
+	// como fonemas
+	if acceptsPhonemes && inlinePhonemes.MatchString(req.Text) {
+		phonemes, err := m.phonemize(ctx, phonemeEngine, req.Voice, req.Text)
+		if err != nil {
+			return nil, err
+		}
+		req.Text, req.Phonemes = phonemes, true
+	}
+
+	return m.engine.Synthesize(ctx, req)
+}
+
+// Phonemize retorna os fonemas IPA que a voz usaria para o texto, após o
+// léxico e a normalização
+func (m *Manager) Phonemize(ctx context.Context, voice, text string, skipNormalization bool) (string, error) {
+	info, exists := m.engine.Voice(voice)
 	if !exists {
 		return "", fmt.Errorf("voz %s não encontrada", voice)
 	}
-	return path, nil
+	if text == "" {
+		return "", fmt.Errorf("texto não pode estar vazio")
+	}
+	phonemeEngine, ok := m.engine.(PhonemeEngine)
+	if !ok {
+		return "", fmt.Errorf("%w pelo engine %s", ErrPhonemesUnsupported, m.Config.Engine)
+	}
+	return m.phonemize(ctx, phonemeEngine, voice, m.PrepareText(info, text, skipNormalization))
+}
+
+// phonemize converte os trechos de texto e mantém os trechos [[ ]] já em
+// fonemas
+func (m *Manager) phonemize(ctx context.Context, engine PhonemeEngine, voice, text string) (string, error) {
+	var out []string
+	for _, part := range splitPhonemes(text) {
+		if part.phonemes {
+			out = append(out, part.text)
+			continue
+		}
+		if strings.TrimSpace(part.text) == "" {
+			continue
+		}
+		phonemes, err := engine.Phonemize(ctx, voice, part.text)
+		if err != nil {
+			return "", err
+		}
+		out = append(out, phonemes)
+	}
+	return strings.Join(out, " "), nil
+}
+
+// PrepareText aplica o léxico da voz e, se ativa, a normalização conforme o
+// idioma, resultando no texto enviado ao engine. Os fonemas do léxico, entre
+// [[ ]], não são normalizados. Sem o nome da voz, apenas a normalização é
+// aplicada.
+func (m *Manager) PrepareText(info VoiceInfo, text string, skipNormalization bool) string {
+	if info.Name != "" {
+		text = m.lexicons.Apply(info.Name, text)
+	}
+	if !m.Config.TextNormalization || skipNormalization {
+		return text
+	}
+
+	var b strings.Builder
+	for _, part := range splitPhonemes(text) {
+		if part.phonemes {
+			b.WriteString("[[ " + part.text + " ]]")
+		} else {
+			b.WriteString(normalize.Text(info.Language, part.text))
+		}
+	}
+	return b.String()
+}
+
+// ModelVersion identifica o modelo atual da voz; vazio quando o engine não
+// informa a versão
+func (m *Manager) ModelVersion(voice string) (string, error) {
+	versioner, ok := m.engine.(ModelVersioner)
+	if !ok {
+		return "", nil
+	}
+	return versioner.ModelVersion(voice)
+}
+
+// Lexicons retorna os léxicos de pronúncia das vozes
+func (m *Manager) Lexicons() *lexicon.Store {
+	return m.lexicons
+}
+
+// ListVoices retorna as vozes disponíveis em ordem alfabética
+func (m *Manager) ListVoices() []string {
+	voices := m.engine.Voices()
+	sort.Strings(voices)
+	return voices
+}
+
+// HasVoice informa se a voz está disponível
+func (m *Manager) HasVoice(voice string) bool {
+	_, exists := m.engine.Voice(voice)
+	return exists
+}
+
+// Voice retorna os detalhes de uma voz
+func (m *Manager) Voice(name string) (VoiceInfo, bool) {
+	return m.engine.Voice(name)
 }
 
 func (m *Manager) GetVoicesDir() string {
-	return m.voicesDir
+	return m.Config.VoicesDir
 }
 
+// Close interrompe o monitoramento de VoicesDir e encerra o engine de
+// síntese
 func (m *Manager) Close() {
-	// Método mantido vazio para compatibilidade com a interface existente
+	if m.watcher != nil {
+		m.watcher.close()
+	}
+	m.engine.Close()
 }
@@ -0,0 +1,73 @@
+package voice
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Metadata representa o arquivo .onnx.json que acompanha cada modelo do piper
+type Metadata struct {
+	Audio struct {
+		SampleRate int    `json:"sample_rate"`
+		Quality    string `json:"quality"`
+	} `json:"audio"`
+	Espeak struct {
+		Voice string `json:"voice"`
+	} `json:"espeak"`
+	Inference struct {
+		NoiseScale  *float64 `json:"noise_scale"`
+		LengthScale *float64 `json:"length_scale"`
+		NoiseW      *float64 `json:"noise_w"`
+	} `json:"inference"`
+	Language struct {
+		Code string `json:"code"`
+	} `json:"language"`
+	NumSpeakers  int              `json:"num_speakers"`
+	SpeakerIDMap map[string]int   `json:"speaker_id_map"`
+	PhonemeType  string           `json:"phoneme_type"` // "espeak" (padrão) ou "text"
+	PhonemeIDMap map[string][]int `json:"phoneme_id_map"`
+}
+
+// LoadMetadata lê o arquivo de configuração de uma voz
+func LoadMetadata(configPath string) (*Metadata, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler configuração da voz: %v", err)
+	}
+
+	var meta Metadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar configuração da voz %s: %v", configPath, err)
+	}
+	return &meta, nil
+}
+
+// LanguageCode retorna o idioma da voz no formato pt_BR. Modelos antigos sem
+// o bloco language usam a voz do espeak (pt-br).
+func (m *Metadata) LanguageCode() string {
+	if m.Language.Code != "" {
+		return m.Language.Code
+	}
+	lang, region, found := strings.Cut(m.Espeak.Voice, "-")
+	if !found {
+		return lang
+	}
+	return lang + "_" + strings.ToUpper(region)
+}
+
+// AcceptsPhonemes indica se o modelo foi treinado com fonemas do espeak e,
+// portanto, pode receber fonemas IPA diretamente
+func (m *Metadata) AcceptsPhonemes() bool {
+	return (m.PhonemeType == "" || m.PhonemeType == "espeak") && len(m.PhonemeIDMap) > 0
+}
+
+// Defaults retorna os parâmetros de prosódia padrão definidos pela voz
+func (m *Metadata) Defaults() Options {
+	return Options{
+		LengthScale: m.Inference.LengthScale,
+		NoiseScale:  m.Inference.NoiseScale,
+		NoiseW:      m.Inference.NoiseW,
+	}.WithDefaults(defaultOptions)
+}
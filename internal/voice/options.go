@@ -0,0 +1,121 @@
+package voice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Options ajusta a prosódia da síntese. Campos nulos usam o padrão da voz,
+// lido da seção "inference" do arquivo .onnx.json.
+type Options struct {
+	LengthScale     *float64 // duração dos fonemas (maior = fala mais lenta)
+	NoiseScale      *float64 // variação de entonação
+	NoiseW          *float64 // variação na duração dos fonemas
+	SentenceSilence *float64 // segundos de silêncio após cada frase
+}
+
+// Range define o intervalo aceito para um parâmetro de prosódia
+type Range struct {
+	Min float64
+	Max float64
+}
+
+// Intervalos aceitos para os parâmetros de prosódia
+var (
+	SpeedRange           = Range{Min: 0.25, Max: 4}
+	LengthScaleRange     = Range{Min: 0.25, Max: 4}
+	NoiseScaleRange      = Range{Min: 0, Max: 2}
+	NoiseWRange          = Range{Min: 0, Max: 2}
+	SentenceSilenceRange = Range{Min: 0, Max: 5}
+)
+
+// Valores padrão do piper quando a voz não define a seção "inference"
+var defaultOptions = Options{
+	LengthScale:     float64Ptr(1),
+	NoiseScale:      float64Ptr(0.667),
+	NoiseW:          float64Ptr(0.8),
+	SentenceSilence: float64Ptr(0.2),
+}
+
+// RangeError indica um parâmetro fora do intervalo permitido
+type RangeError struct {
+	Field string
+	Value float64
+	Range Range
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("%s deve estar entre %g e %g (recebido %g)", e.Field, e.Range.Min, e.Range.Max, e.Value)
+}
+
+// Check retorna um RangeError se o valor estiver fora do intervalo
+func (r Range) Check(field string, value float64) error {
+	if value < r.Min || value > r.Max {
+		return &RangeError{Field: field, Value: value, Range: r}
+	}
+	return nil
+}
+
+// Validate verifica os parâmetros informados
+func (o Options) Validate() error {
+	checks := []struct {
+		field string
+		value *float64
+		rng   Range
+	}{
+		{"length_scale", o.LengthScale, LengthScaleRange},
+		{"noise_scale", o.NoiseScale, NoiseScaleRange},
+		{"noise_w", o.NoiseW, NoiseWRange},
+		{"sentence_silence", o.SentenceSilence, SentenceSilenceRange},
+	}
+	for _, c := range checks {
+		if c.value == nil {
+			continue
+		}
+		if err := c.rng.Check(c.field, *c.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithDefaults preenche os campos nulos com os valores de defaults
+func (o Options) WithDefaults(defaults Options) Options {
+	if o.LengthScale == nil {
+		o.LengthScale = defaults.LengthScale
+	}
+	if o.NoiseScale == nil {
+		o.NoiseScale = defaults.NoiseScale
+	}
+	if o.NoiseW == nil {
+		o.NoiseW = defaults.NoiseW
+	}
+	if o.SentenceSilence == nil {
+		o.SentenceSilence = defaults.SentenceSilence
+	}
+	return o
+}
+
+// Equal compara os valores (e não os ponteiros) de duas opções
+func (o Options) Equal(other Options) bool {
+	return float64Equal(o.LengthScale, other.LengthScale) &&
+		float64Equal(o.NoiseScale, other.NoiseScale) &&
+		float64Equal(o.NoiseW, other.NoiseW) &&
+		float64Equal(o.SentenceSilence, other.SentenceSilence)
+}
+
+// String descreve os parâmetros preenchidos como argumentos do piper
+func (o Options) String() string {
+	return strings.Join(prosodyArgs(o), " ")
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+func float64Equal(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,94 @@
+package voice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ErrPhonemesUnsupported é retornado quando o engine, a voz ou o ambiente
+// (espeak-ng ausente) não permitem entrada ou fonemização em IPA
+var ErrPhonemesUnsupported = errors.New("fonemas não suportados")
+
+// inlinePhonemes encontra os trechos [[ ... ]] inseridos pelo léxico
+var inlinePhonemes = regexp.MustCompile(`\[\[(.*?)\]\]`)
+
+// textPart é um trecho do texto: texto comum ou fonemas IPA
+type textPart struct {
+	text     string
+	phonemes bool
+}
+
+// splitPhonemes separa os trechos [[ ]] do restante do texto
+func splitPhonemes(text string) []textPart {
+	var parts []textPart
+	last := 0
+	for _, loc := range inlinePhonemes.FindAllStringSubmatchIndex(text, -1) {
+		if loc[0] > last {
+			parts = append(parts, textPart{text: text[last:loc[0]]})
+		}
+		parts = append(parts, textPart{text: strings.TrimSpace(text[loc[2]:loc[3]]), phonemes: true})
+		last = loc[1]
+	}
+	if last < len(text) {
+		parts = append(parts, textPart{text: text[last:]})
+	}
+	return parts
+}
+
+// phonemeSet contém os símbolos do phoneme_id_map de uma voz
+type phonemeSet map[rune]bool
+
+func newPhonemeSet(idMap map[string][]int) phonemeSet {
+	set := make(phonemeSet)
+	for symbol := range idMap {
+		for _, r := range symbol {
+			set[r] = true
+		}
+	}
+	return set
+}
+
+// validate retorna erro listando os símbolos que a voz não conhece
+func (s phonemeSet) validate(voice, phonemes string) error {
+	seen := make(map[rune]bool)
+	var unknown []string
+	for _, r := range phonemes {
+		if unicode.IsSpace(r) || s[r] || seen[r] {
+			continue
+		}
+		seen[r] = true
+		unknown = append(unknown, fmt.Sprintf("%q", r))
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("fonemas desconhecidos pela voz %s: %s", voice, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// writePhonemeConfig grava uma cópia da configuração da voz com
+// phoneme_type "text", que faz o piper ler a entrada como fonemas em vez de
+// passá-la pelo espeak
+func writePhonemeConfig(configPath, dest string) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+	config["phoneme_type"] = json.RawMessage(`"text"`)
+
+	data, err = json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dest, data, 0644)
+}
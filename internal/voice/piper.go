@@ -0,0 +1,314 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"tts-api/internal/config"
+)
+
+// PiperEngine sintetiza áudio executando o binário do piper
+type PiperEngine struct {
+	cfg    *config.Config
+	voices map[string]*piperVoice
+	binary string
+	mu     sync.RWMutex
+
+	espeakBinary string
+	espeakData   string
+	phonemeDir   string // Configurações derivadas para entrada em fonemas
+}
+
+// piperVoice guarda o diretório, os metadados e o pool de uma voz
+type piperVoice struct {
+	dir  string
+	info VoiceInfo
+	pool *Pool // nil quando o pool está desativado
+
+	prosody *prosodyPools // Workers para prosódia personalizada (nil desativa)
+
+	inflight sync.WaitGroup // Requisições em andamento, aguardadas ao descartar a voz
+
+	espeakVoice   string
+	phonemes      phonemeSet // nil quando a voz não aceita fonemas
+	phonemeConfig string
+}
+
+// NewPiperEngine carrega as vozes encontradas em cfg.VoicesDir
+func NewPiperEngine(cfg *config.Config) (*PiperEngine, error) {
+	voicesDir := cfg.VoicesDir
+	if err := os.MkdirAll(voicesDir, 0755); err != nil {
+		return nil, fmt.Errorf("falha ao criar diretório de vozes: %v", err)
+	}
+
+	entries, err := os.ReadDir(voicesDir)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler diretório de vozes: %v", err)
+	}
+
+	phonemeDir, err := os.MkdirTemp("", "gotts-phonemes-")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %v", err)
+	}
+
+	e := &PiperEngine{
+		cfg:          cfg,
+		voices:       make(map[string]*piperVoice),
+		binary:       cfg.PiperBinary,
+		espeakBinary: cfg.EspeakBinary,
+		espeakData:   cfg.EspeakData,
+		phonemeDir:   phonemeDir,
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		v, _ := e.newVoice(entry.Name(), false)
+		e.voices[v.info.Name] = v
+		log.Printf("Voz encontrada: %s", v.info.Name)
+	}
+
+	return e, nil
+}
+
+// newVoice lê os metadados e inicia o pool da voz. Com strict, o modelo e
+// a configuração precisam existir e ser válidos; sem ele, a voz é criada
+// mesmo assim, como na carga inicial.
+func (e *PiperEngine) newVoice(voiceName string, strict bool) (*piperVoice, error) {
+	voicePath := filepath.Join(e.cfg.VoicesDir, voiceName)
+	v := &piperVoice{
+		dir:  voicePath,
+		info: VoiceInfo{Name: voiceName, Defaults: defaultOptions},
+	}
+
+	_, configPath, err := findModel(voicePath)
+	if err == nil {
+		var meta *Metadata
+		if meta, err = LoadMetadata(configPath); err == nil {
+			v.info.SampleRate = meta.Audio.SampleRate
+			v.info.Defaults = meta.Defaults()
+			v.info.Speakers = meta.Speakers()
+			v.info.Language = meta.LanguageCode()
+			v.espeakVoice = meta.Espeak.Voice
+			if meta.AcceptsPhonemes() {
+				e.enablePhonemes(v, configPath, meta)
+			}
+		} else if !strict {
+			log.Printf("Aviso: %v", err)
+		}
+	}
+	if err != nil && strict {
+		return nil, err
+	}
+
+	if size := e.cfg.VoicePoolSize(voiceName); size > 0 {
+		pool, err := NewPool(voiceName, e.cfg.PiperBinary, voicePath, Options{}, size, e.cfg.WorkerMaxRequests)
+		if err != nil {
+			log.Printf("Aviso: pool de workers desativado para a voz %s: %v", voiceName, err)
+		} else {
+			v.pool = pool
+			if e.cfg.ProsodyPools > 0 {
+				v.prosody = newProsodyPools(voiceName, e.cfg.PiperBinary, voicePath, e.cfg.ProsodyPools, e.cfg.WorkerMaxRequests)
+			}
+		}
+	}
+	return v, nil
+}
+
+// LoadVoice valida e registra a voz do diretório VoicesDir/name. Uma voz já
+// registrada é substituída e a versão anterior é descartada após o término
+// das requisições em andamento.
+func (e *PiperEngine) LoadVoice(name string) error {
+	v, err := e.newVoice(name, true)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	old := e.voices[name]
+	e.voices[name] = v
+	e.mu.Unlock()
+
+	if old != nil {
+		go e.retire(old)
+	}
+	return nil
+}
+
+// UnloadVoice remove a voz. Novas requisições passam a falhar e os recursos
+// são liberados após o término das requisições em andamento.
+func (e *PiperEngine) UnloadVoice(name string) error {
+	e.mu.Lock()
+	v, exists := e.voices[name]
+	delete(e.voices, name)
+	e.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("voz %s não encontrada", name)
+	}
+	go e.retire(v)
+	return nil
+}
+
+// retire aguarda as requisições em andamento e encerra os workers da voz
+func (e *PiperEngine) retire(v *piperVoice) {
+	v.inflight.Wait()
+	if v.pool != nil {
+		v.pool.Close()
+	}
+	if v.prosody != nil {
+		v.prosody.close()
+	}
+	if v.phonemeConfig != "" {
+		os.Remove(v.phonemeConfig)
+	}
+}
+
+// acquire retorna a voz e registra uma requisição em andamento, que deve
+// ser encerrada com v.inflight.Done()
+func (e *PiperEngine) acquire(name string) (*piperVoice, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	v, exists := e.voices[name]
+	if exists {
+		v.inflight.Add(1)
+	}
+	return v, exists
+}
+
+func (e *PiperEngine) Synthesize(ctx context.Context, req Request) ([]byte, error) {
+	v, exists := e.acquire(req.Voice)
+	if !exists {
+		return nil, fmt.Errorf("voz %s não encontrada", req.Voice)
+	}
+	defer v.inflight.Done()
+
+	// O piper mantém o último locutor usado pelo worker, então em modelos
+	// multi-locutor o id é sempre enviado
+	speakerID := req.SpeakerID
+	if speakerID == nil && len(v.info.Speakers) > 0 {
+		speakerID = &v.info.Speakers[0].ID
+	}
+
+	// Fonemas usam a configuração derivada, carregada por um processo
+	// dedicado
+	if req.Phonemes {
+		if v.phonemes == nil {
+			return nil, fmt.Errorf("%w: a voz %s não aceita fonemas", ErrPhonemesUnsupported, req.Voice)
+		}
+		if err := v.phonemes.validate(req.Voice, req.Text); err != nil {
+			return nil, err
+		}
+		modelPath, _, err := findModel(v.dir)
+		if err != nil {
+			return nil, err
+		}
+		return synthesizeModel(ctx, e.binary, modelPath, v.phonemeConfig, req.Text, speakerID, req.Options)
+	}
+
+	// Os workers do pool usam a prosódia padrão da voz; parâmetros
+	// personalizados usam os workers da prosódia ou, se o pool dela foi
+	// descartado, um processo dedicado
+	if v.pool != nil && req.Options.Equal(v.info.Defaults) {
+		return v.pool.Synthesize(ctx, req.Text, speakerID)
+	}
+	if v.prosody != nil {
+		if pool, err := v.prosody.get(req.Options); err == nil {
+			audio, err := pool.Synthesize(ctx, req.Text, speakerID)
+			if !errors.Is(err, ErrPoolClosed) {
+				return audio, err
+			}
+		}
+	}
+	return Synthesize(ctx, e.binary, v.dir, req.Text, speakerID, req.Options)
+}
+
+// Phonemize retorna os fonemas que o piper obteria do espeak-ng para o texto
+func (e *PiperEngine) Phonemize(ctx context.Context, voice, text string) (string, error) {
+	e.mu.RLock()
+	v, exists := e.voices[voice]
+	e.mu.RUnlock()
+
+	if !exists {
+		return "", fmt.Errorf("voz %s não encontrada", voice)
+	}
+	if v.espeakVoice == "" {
+		return "", fmt.Errorf("%w: a voz %s não usa o espeak", ErrPhonemesUnsupported, voice)
+	}
+	return espeakPhonemize(ctx, e.espeakBinary, e.espeakData, v.espeakVoice, text)
+}
+
+// ModelVersion identifica o modelo atual da voz
+func (e *PiperEngine) ModelVersion(voice string) (string, error) {
+	e.mu.RLock()
+	v, exists := e.voices[voice]
+	e.mu.RUnlock()
+	if !exists {
+		return "", fmt.Errorf("voz %s não encontrada", voice)
+	}
+
+	return modelFingerprint(v.dir)
+}
+
+// enablePhonemes grava a configuração derivada que permite enviar fonemas
+// à voz
+func (e *PiperEngine) enablePhonemes(v *piperVoice, configPath string, meta *Metadata) {
+	// O nome é único para não alterar a configuração de uma versão anterior
+	// da voz ainda em uso
+	dest := filepath.Join(e.phonemeDir, fmt.Sprintf("%s.%d.onnx.json", v.info.Name, time.Now().UnixNano()))
+	if err := writePhonemeConfig(configPath, dest); err != nil {
+		log.Printf("Aviso: entrada em fonemas desativada para a voz %s: %v", v.info.Name, err)
+		return
+	}
+	v.phonemes = newPhonemeSet(meta.PhonemeIDMap)
+	v.phonemeConfig = dest
+}
+
+func (e *PiperEngine) Voices() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	voices := make([]string, 0, len(e.voices))
+	for voice := range e.voices {
+		voices = append(voices, voice)
+	}
+	return voices
+}
+
+func (e *PiperEngine) Voice(name string) (VoiceInfo, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	v, exists := e.voices[name]
+	if !exists {
+		return VoiceInfo{}, false
+	}
+	return v.info, true
+}
+
+// Close encerra os workers piper de todas as vozes e remove as
+// configurações derivadas
+func (e *PiperEngine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, v := range e.voices {
+		if v.pool != nil {
+			v.pool.Close()
+			v.pool = nil
+		}
+		if v.prosody != nil {
+			v.prosody.close()
+			v.prosody = nil
+		}
+	}
+	return os.RemoveAll(e.phonemeDir)
+}
@@ -0,0 +1,374 @@
+package voice
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrPoolClosed é retornado quando o pool já foi encerrado
+var ErrPoolClosed = errors.New("pool de workers encerrado")
+
+// Pool mantém processos piper de longa duração para uma voz, evitando
+// recarregar o modelo ONNX a cada requisição
+type Pool struct {
+	name        string
+	binary      string
+	modelPath   string
+	configPath  string
+	maxRequests int
+	outDir      string
+	args        []string // Prosódia passada aos workers; vazia usa o padrão da voz
+
+	// slots contém um item por worker: nil indica que o worker ainda não
+	// foi iniciado (ou foi descartado) e deve ser criado sob demanda
+	slots chan *worker
+	seq   atomic.Uint64
+
+	// done é fechado por Close e libera as requisições que aguardam um
+	// worker
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewPool cria um pool com size workers para a voz no diretório informado,
+// que sintetizam com a prosódia de opts (os campos nil usam o padrão da
+// voz). Os processos são iniciados apenas quando a primeira requisição chega.
+func NewPool(name, binary, voiceDir string, opts Options, size, maxRequests int) (*Pool, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("tamanho de pool inválido: %d", size)
+	}
+
+	modelPath, configPath, err := findModel(voiceDir)
+	if err != nil {
+		return nil, err
+	}
+
+	outDir, err := os.MkdirTemp("", "gotts-"+name+"-")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %v", err)
+	}
+
+	p := &Pool{
+		name:        name,
+		binary:      binary,
+		modelPath:   modelPath,
+		configPath:  configPath,
+		maxRequests: maxRequests,
+		outDir:      outDir,
+		args:        prosodyArgs(opts),
+		slots:       make(chan *worker, size),
+		done:        make(chan struct{}),
+	}
+	for i := 0; i < size; i++ {
+		p.slots <- nil
+	}
+	return p, nil
+}
+
+// Synthesize envia o texto para um worker ocioso e retorna o WAV gerado.
+// speakerID pode ser nil para modelos com um único locutor.
+func (p *Pool) Synthesize(ctx context.Context, text string, speakerID *int) ([]byte, error) {
+	if p.isClosed() {
+		return nil, ErrPoolClosed
+	}
+
+	var w *worker
+	select {
+	case w = <-p.slots:
+	case <-p.done:
+		return nil, ErrPoolClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	// Close pode ter começado enquanto o worker era obtido
+	if p.isClosed() {
+		p.slots <- w
+		return nil, ErrPoolClosed
+	}
+
+	if w != nil && w.exited() {
+		log.Printf("Worker piper da voz %s encerrou inesperadamente, reiniciando", p.name)
+		w.stop()
+		w = nil
+	}
+	if w == nil {
+		var err error
+		if w, err = p.spawn(); err != nil {
+			p.slots <- nil
+			return nil, err
+		}
+	}
+
+	outPath := filepath.Join(p.outDir, fmt.Sprintf("%d.wav", p.seq.Add(1)))
+	audio, err := w.synthesize(ctx, pipeRequest{
+		Text:       prepareText(text),
+		SpeakerID:  speakerID,
+		OutputFile: outPath,
+	})
+	if err != nil {
+		w.stop()
+		p.slots <- nil
+		return nil, err
+	}
+
+	if p.maxRequests > 0 && w.served >= p.maxRequests {
+		log.Printf("Reciclando worker piper da voz %s após %d requisições", p.name, w.served)
+		go w.stop()
+		w = nil
+	}
+	p.slots <- w
+	return audio, nil
+}
+
+// Close encerra todos os workers, aguardando os que estão em uso. As
+// requisições que aguardam um worker recebem ErrPoolClosed.
+func (p *Pool) Close() {
+	closing := false
+	p.closeOnce.Do(func() {
+		close(p.done)
+		closing = true
+	})
+	if !closing {
+		return
+	}
+
+	for i := 0; i < cap(p.slots); i++ {
+		if w := <-p.slots; w != nil {
+			w.stop()
+		}
+	}
+	os.RemoveAll(p.outDir)
+}
+
+func (p *Pool) isClosed() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *Pool) spawn() (*worker, error) {
+	args := append([]string{
+		"--model", p.modelPath,
+		"--config", p.configPath,
+		"--json-input",
+		"--output_dir", p.outDir,
+	}, p.args...)
+	cmd := exec.Command(p.binary, args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr := &tailBuffer{limit: 4096}
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("erro ao iniciar worker piper: %v", err)
+	}
+
+	w := &worker{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: bufio.NewReader(stdout),
+		stderr: stderr,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		cmd.Wait()
+		close(w.done)
+	}()
+
+	log.Printf("Worker piper iniciado para a voz %s (pid %d)", p.name, cmd.Process.Pid)
+	return w, nil
+}
+
+// prosodyPools mantém um worker para cada um dos conjuntos de prosódia
+// personalizada usados mais recentemente em uma voz, já que o piper só
+// aceita a prosódia como argumento do processo
+type prosodyPools struct {
+	name        string
+	binary      string
+	voiceDir    string
+	limit       int
+	maxRequests int
+
+	mu    sync.Mutex
+	pools []prosodyPool // Do uso mais recente para o mais antigo
+}
+
+type prosodyPool struct {
+	key  string
+	pool *Pool
+}
+
+func newProsodyPools(name, binary, voiceDir string, limit, maxRequests int) *prosodyPools {
+	return &prosodyPools{name: name, binary: binary, voiceDir: voiceDir, limit: limit, maxRequests: maxRequests}
+}
+
+// get retorna o pool da prosódia, criando-o se necessário. O pool usado há
+// mais tempo é encerrado ao exceder o limite; suas requisições em espera
+// recebem ErrPoolClosed.
+func (pp *prosodyPools) get(opts Options) (*Pool, error) {
+	key := opts.String()
+
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	for i, entry := range pp.pools {
+		if entry.key == key {
+			copy(pp.pools[1:i+1], pp.pools[:i])
+			pp.pools[0] = entry
+			return entry.pool, nil
+		}
+	}
+
+	pool, err := NewPool(pp.name, pp.binary, pp.voiceDir, opts, 1, pp.maxRequests)
+	if err != nil {
+		return nil, err
+	}
+	pp.pools = append([]prosodyPool{{key: key, pool: pool}}, pp.pools...)
+	if len(pp.pools) > pp.limit {
+		oldest := pp.pools[len(pp.pools)-1]
+		pp.pools = pp.pools[:len(pp.pools)-1]
+		go oldest.pool.Close()
+	}
+	return pool, nil
+}
+
+// close encerra os workers de todas as prosódias
+func (pp *prosodyPools) close() {
+	pp.mu.Lock()
+	pools := pp.pools
+	pp.pools = nil
+	pp.mu.Unlock()
+
+	for _, entry := range pools {
+		entry.pool.Close()
+	}
+}
+
+// worker é um processo piper em modo --json-input
+type worker struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	stderr *tailBuffer
+	served int
+	done   chan struct{}
+}
+
+// pipeRequest é a linha JSON enviada ao piper
+type pipeRequest struct {
+	Text       string `json:"text"`
+	SpeakerID  *int   `json:"speaker_id,omitempty"`
+	OutputFile string `json:"output_file"`
+}
+
+func (w *worker) synthesize(ctx context.Context, req pipeRequest) ([]byte, error) {
+	outPath := req.OutputFile
+	line, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		if _, err := w.stdin.Write(append(line, '\n')); err != nil {
+			result <- err
+			return
+		}
+		// O piper escreve no stdout o caminho de cada arquivo gerado
+		for {
+			l, err := w.stdout.ReadString('\n')
+			if err != nil {
+				result <- err
+				return
+			}
+			if strings.TrimSpace(l) == outPath {
+				result <- nil
+				return
+			}
+		}
+	}()
+
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		w.kill()
+		<-result
+		os.Remove(outPath)
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro na síntese: %v: %s", err, w.stderr.String())
+	}
+
+	audio, err := os.ReadFile(outPath)
+	os.Remove(outPath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler áudio gerado: %v", err)
+	}
+
+	w.served++
+	return audio, nil
+}
+
+func (w *worker) exited() bool {
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *worker) kill() {
+	w.cmd.Process.Kill()
+}
+
+// stop fecha o stdin, o que faz o piper encerrar após a linha atual
+func (w *worker) stop() {
+	w.stdin.Close()
+	<-w.done
+}
+
+// tailBuffer guarda apenas os últimos bytes escritos no stderr do worker
+type tailBuffer struct {
+	mu    sync.Mutex
+	buf   []byte
+	limit int
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > t.limit {
+		t.buf = t.buf[len(t.buf)-t.limit:]
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.buf)
+}
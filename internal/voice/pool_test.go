@@ -0,0 +1,250 @@
+package voice
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakePiperEnv faz o binário de teste se comportar como o piper em modo
+// --json-input, servindo de worker para os testes do pool
+const fakePiperEnv = "GOTTS_FAKE_PIPER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakePiperEnv) == "1" {
+		fakePiper()
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// fakePiper grava em cada arquivo pedido o pid do processo e os argumentos
+// recebidos. Textos com SLEEP demoram e textos com CRASH encerram o processo.
+func fakePiper() {
+	args := strings.Join(os.Args[1:], " ")
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var req pipeRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			os.Exit(2)
+		}
+		switch {
+		case strings.Contains(req.Text, "CRASH"):
+			os.Exit(1)
+		case strings.Contains(req.Text, "SLEEP"):
+			time.Sleep(time.Second)
+		}
+		os.WriteFile(req.OutputFile, []byte(fmt.Sprintf("%d %s", os.Getpid(), args)), 0644)
+		fmt.Println(req.OutputFile)
+	}
+}
+
+// fakeVoiceDir cria uma voz com modelo vazio e aponta o pool para o binário
+// de teste
+func fakeVoiceDir(t *testing.T) (binary, dir string) {
+	t.Helper()
+	t.Setenv(fakePiperEnv, "1")
+	dir = t.TempDir()
+	for _, name := range []string{"teste.onnx", "teste.onnx.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return os.Args[0], dir
+}
+
+func newFakePool(t *testing.T, size, maxRequests int) *Pool {
+	t.Helper()
+	binary, dir := fakeVoiceDir(t)
+	p, err := NewPool("teste", binary, dir, Options{}, size, maxRequests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(p.Close)
+	return p
+}
+
+// workerPid sintetiza o texto e retorna o pid do worker que o atendeu
+func workerPid(t *testing.T, p *Pool, text string) string {
+	t.Helper()
+	data, err := p.Synthesize(context.Background(), text, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, _, _ := strings.Cut(string(data), " ")
+	return pid
+}
+
+func TestPoolRecyclesWorkers(t *testing.T) {
+	p := newFakePool(t, 1, 2)
+
+	first, second := workerPid(t, p, "um"), workerPid(t, p, "dois")
+	third, fourth := workerPid(t, p, "três"), workerPid(t, p, "quatro")
+	if first != second || third != fourth {
+		t.Fatalf("worker trocado antes de maxRequests: %s %s %s %s", first, second, third, fourth)
+	}
+	if first == third {
+		t.Fatalf("worker %s não foi reciclado após 2 requisições", first)
+	}
+}
+
+func TestPoolRestartsCrashedWorker(t *testing.T) {
+	p := newFakePool(t, 1, 0)
+
+	before := workerPid(t, p, "antes")
+	if _, err := p.Synthesize(context.Background(), "CRASH", nil); err == nil {
+		t.Fatal("falha do worker não foi retornada")
+	}
+	if after := workerPid(t, p, "depois"); after == before {
+		t.Fatalf("worker %s não foi substituído após a falha", before)
+	}
+}
+
+func TestPoolContextCancel(t *testing.T) {
+	p := newFakePool(t, 1, 0)
+	before := workerPid(t, p, "antes")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := p.Synthesize(ctx, "SLEEP", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("erro %v, esperado context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("cancelamento levou %v", elapsed)
+	}
+
+	// O worker interrompido é descartado e o slot volta ao pool
+	if after := workerPid(t, p, "depois"); after == before {
+		t.Fatalf("worker %s interrompido continuou em uso", before)
+	}
+
+	// Com o único worker ocupado, a espera também respeita o contexto
+	busy := make(chan struct{})
+	go func() {
+		defer close(busy)
+		p.Synthesize(context.Background(), "SLEEP", nil)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := p.Synthesize(ctx, "espera", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("espera por worker: %v, esperado context.DeadlineExceeded", err)
+	}
+	<-busy
+}
+
+func TestPoolCloseWhileWaiting(t *testing.T) {
+	p := newFakePool(t, 1, 0)
+
+	type result struct {
+		data []byte
+		err  error
+	}
+	running := make(chan result, 1)
+	go func() {
+		data, err := p.Synthesize(context.Background(), "SLEEP", nil)
+		running <- result{data, err}
+	}()
+	time.Sleep(200 * time.Millisecond)
+
+	waiting := make(chan error, 1)
+	go func() {
+		_, err := p.Synthesize(context.Background(), "espera", nil)
+		waiting <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		p.Close()
+		close(closed)
+	}()
+
+	select {
+	case err := <-waiting:
+		if !errors.Is(err, ErrPoolClosed) {
+			t.Fatalf("requisição em espera: %v, esperado ErrPoolClosed", err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("requisição em espera não foi liberada por Close")
+	}
+
+	// Close aguarda a síntese em andamento, que termina normalmente
+	if r := <-running; r.err != nil || len(r.data) == 0 {
+		t.Fatalf("síntese em andamento: %v", r.err)
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close não retornou após a síntese em andamento")
+	}
+
+	if _, err := p.Synthesize(context.Background(), "depois", nil); !errors.Is(err, ErrPoolClosed) {
+		t.Fatalf("síntese após Close: %v, esperado ErrPoolClosed", err)
+	}
+	if _, err := os.Stat(p.outDir); !os.IsNotExist(err) {
+		t.Errorf("diretório temporário não removido: %v", err)
+	}
+}
+
+func TestProsodyPoolsEviction(t *testing.T) {
+	binary, dir := fakeVoiceDir(t)
+	pp := newProsodyPools("teste", binary, dir, 2, 0)
+	t.Cleanup(pp.close)
+
+	scale := func(v float64) Options { return Options{LengthScale: &v} }
+	get := func(opts Options) *Pool {
+		t.Helper()
+		p, err := pp.get(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	slow, fast := get(scale(1.5)), get(scale(0.8))
+	if get(scale(1.5)) != slow {
+		t.Fatal("a mesma prosódia criou outro pool")
+	}
+
+	// A prosódia é passada ao worker como argumento
+	data, err := slow.Synthesize(context.Background(), "olá", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "--length_scale 1.5") {
+		t.Errorf("argumentos do worker: %q", data)
+	}
+
+	// 1.5 foi usada por último, então 0.8 é a descartada
+	get(scale(1.2))
+	var keys []string
+	for _, entry := range pp.pools {
+		keys = append(keys, entry.key)
+	}
+	if want := []string{scale(1.2).String(), scale(1.5).String()}; strings.Join(keys, "|") != strings.Join(want, "|") {
+		t.Fatalf("pools mantidos %v, esperado %v", keys, want)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !fast.isClosed() {
+		if time.Now().After(deadline) {
+			t.Fatal("pool descartado não foi encerrado")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := fast.Synthesize(context.Background(), "olá", nil); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("pool descartado: %v, esperado ErrPoolClosed", err)
+	}
+	if slow.isClosed() {
+		t.Error("pool usado recentemente foi encerrado")
+	}
+}
@@ -0,0 +1,71 @@
+package voice
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Speaker é um dos locutores de um modelo multi-locutor
+type Speaker struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// SpeakerError indica um locutor inexistente na voz escolhida
+type SpeakerError struct {
+	Voice     string
+	Speaker   string
+	Available []Speaker
+}
+
+func (e *SpeakerError) Error() string {
+	if len(e.Available) == 0 {
+		return fmt.Sprintf("a voz %s não possui múltiplos locutores", e.Voice)
+	}
+	return fmt.Sprintf("locutor %s não encontrado para a voz %s", e.Speaker, e.Voice)
+}
+
+// ResolveSpeaker converte um locutor informado por nome ou por id no id
+// numérico usado pelo modelo
+func (v VoiceInfo) ResolveSpeaker(speaker string) (int, error) {
+	for _, s := range v.Speakers {
+		if s.Name == speaker {
+			return s.ID, nil
+		}
+	}
+
+	if id, err := strconv.Atoi(speaker); err == nil {
+		for _, s := range v.Speakers {
+			if s.ID == id {
+				return id, nil
+			}
+		}
+	}
+
+	return 0, &SpeakerError{Voice: v.Name, Speaker: speaker, Available: v.Speakers}
+}
+
+// Speakers lista os locutores do modelo ordenados por id. Modelos com um
+// único locutor retornam uma lista vazia.
+func (m *Metadata) Speakers() []Speaker {
+	if m.NumSpeakers <= 1 {
+		return nil
+	}
+
+	speakers := make([]Speaker, 0, m.NumSpeakers)
+	if len(m.SpeakerIDMap) > 0 {
+		for name, id := range m.SpeakerIDMap {
+			speakers = append(speakers, Speaker{ID: id, Name: name})
+		}
+	} else {
+		for id := 0; id < m.NumSpeakers; id++ {
+			speakers = append(speakers, Speaker{ID: id, Name: strconv.Itoa(id)})
+		}
+	}
+
+	sort.Slice(speakers, func(i, j int) bool {
+		return speakers[i].ID < speakers[j].ID
+	})
+	return speakers
+}
@@ -2,44 +2,41 @@ package voice
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
-func Synthesize(voiceDir string, text string) ([]byte, error) {
-	// Garantir que o texto termine com pontuação
-	if len(text) > 0 && !strings.ContainsAny(text[len(text)-1:], ".!?") {
-		text = text + "."
+// Synthesize executa um processo piper dedicado para sintetizar o texto
+func Synthesize(ctx context.Context, binary, voiceDir, text string, speakerID *int, opts Options) ([]byte, error) {
+	modelPath, configPath, err := findModel(voiceDir)
+	if err != nil {
+		return nil, err
 	}
+	return synthesizeModel(ctx, binary, modelPath, configPath, text, speakerID, opts)
+}
 
-	// Caminhos para os arquivos do modelo e configuração
-	modelPath := ""
-	configPath := ""
-
-	// Procurar pelos arquivos .onnx e .onnx.json no diretório da voz
-	files, err := filepath.Glob(filepath.Join(voiceDir, "*.onnx"))
-	if err != nil || len(files) == 0 {
-		return nil, fmt.Errorf("nenhum arquivo .onnx encontrado na voz %s", voiceDir)
-	}
-	modelPath = files[0]
-	configPath = modelPath + ".json"
-
-	// Verificar se o arquivo de configuração existe
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("arquivo de configuração não encontrado: %s", configPath)
-	} else if err != nil {
-		return nil, fmt.Errorf("erro ao verificar o arquivo de configuração: %v", err)
-	}
+// synthesizeModel executa o piper com o modelo e a configuração informados
+func synthesizeModel(ctx context.Context, binary, modelPath, configPath, text string, speakerID *int, opts Options) ([]byte, error) {
+	text = prepareText(text)
 
 	// Executar o binário do piper
-	cmd := exec.Command("piper",
+	args := []string{
 		"--model", modelPath,
 		"--config", configPath,
 		"--output_file", "-",
-	)
+	}
+	if speakerID != nil {
+		args = append(args, "--speaker", strconv.Itoa(*speakerID))
+	}
+	args = append(args, prosodyArgs(opts)...)
+	cmd := exec.CommandContext(ctx, binary, args...)
 	cmd.Stdin = strings.NewReader(text)
 
 	// Capturar a saída
@@ -55,3 +52,67 @@ func Synthesize(voiceDir string, text string) ([]byte, error) {
 
 	return out.Bytes(), nil
 }
+
+// prosodyArgs converte as opções preenchidas em argumentos do piper
+func prosodyArgs(opts Options) []string {
+	var args []string
+	add := func(flag string, value *float64) {
+		if value != nil {
+			args = append(args, flag, strconv.FormatFloat(*value, 'f', -1, 64))
+		}
+	}
+	add("--length_scale", opts.LengthScale)
+	add("--noise_scale", opts.NoiseScale)
+	add("--noise_w", opts.NoiseW)
+	add("--sentence_silence", opts.SentenceSilence)
+	return args
+}
+
+// prepareText garante que o texto termine com pontuação
+func prepareText(text string) string {
+	if len(text) > 0 && !strings.ContainsAny(text[len(text)-1:], ".!?") {
+		text = text + "."
+	}
+	return text
+}
+
+// findModel procura pelos arquivos .onnx e .onnx.json no diretório da voz
+func findModel(voiceDir string) (modelPath, configPath string, err error) {
+	files, err := filepath.Glob(filepath.Join(voiceDir, "*.onnx"))
+	if err != nil || len(files) == 0 {
+		return "", "", fmt.Errorf("nenhum arquivo .onnx encontrado na voz %s", voiceDir)
+	}
+	modelPath = files[0]
+	configPath = modelPath + ".json"
+
+	// Verificar se o arquivo de configuração existe
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return "", "", fmt.Errorf("arquivo de configuração não encontrado: %s", configPath)
+	} else if err != nil {
+		return "", "", fmt.Errorf("erro ao verificar o arquivo de configuração: %v", err)
+	}
+
+	return modelPath, configPath, nil
+}
+
+// modelFingerprint resume o modelo de uma voz: nome, tamanho e data do
+// .onnx e o conteúdo do .onnx.json
+func modelFingerprint(voiceDir string) (string, error) {
+	modelPath, configPath, err := findModel(voiceDir)
+	if err != nil {
+		return "", err
+	}
+	model, err := os.Stat(modelPath)
+	if err != nil {
+		return "", err
+	}
+	config, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%d\x00%d\x00", filepath.Base(modelPath), model.Size(), model.ModTime().UnixNano())
+	h.Write(config)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,282 @@
+package voice
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// voiceDebounce agrupa os eventos de uma mesma cópia de arquivos
+	voiceDebounce = 500 * time.Millisecond
+	// voiceSettleDelay é o intervalo entre as duas leituras que confirmam
+	// que os arquivos de uma voz pararam de mudar
+	voiceSettleDelay = time.Second
+)
+
+// VoiceEventType é o tipo de mudança em VoicesDir
+type VoiceEventType string
+
+const (
+	VoiceAdded    VoiceEventType = "added"
+	VoiceUpdated  VoiceEventType = "updated"
+	VoiceRemoved  VoiceEventType = "removed"
+	VoiceRejected VoiceEventType = "rejected" // Diretório sem modelo válido
+)
+
+// VoiceEvent descreve uma voz registrada, atualizada ou removida em tempo
+// de execução
+type VoiceEvent struct {
+	Type  VoiceEventType `json:"type"`
+	Voice string         `json:"voice"`
+	Error string         `json:"error,omitempty"`
+	Time  time.Time      `json:"time"`
+}
+
+// voiceWatcher acompanha VoicesDir e registra no engine as vozes
+// adicionadas, alteradas ou removidas. Uma voz só é registrada depois que
+// seus arquivos permanecem iguais em duas leituras seguidas, para não
+// carregar um modelo ainda em cópia.
+type voiceWatcher struct {
+	manager *Manager
+	loader  VoiceLoader
+	dir     string
+
+	known    map[string]string // Versão registrada de cada voz ("" sem modelo)
+	pending  map[string]string // Versões que aguardam a confirmação
+	rejected map[string]string // Versões inválidas, para não repetir o aviso
+
+	mu      sync.Mutex // Protege os mapas, usados também por Manager.LoadVoice
+	started bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newVoiceWatcher(m *Manager, loader VoiceLoader) *voiceWatcher {
+	w := &voiceWatcher{
+		manager:  m,
+		loader:   loader,
+		dir:      m.Config.VoicesDir,
+		known:    make(map[string]string),
+		pending:  make(map[string]string),
+		rejected: make(map[string]string),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for _, name := range m.engine.Voices() {
+		w.known[name], _ = modelFingerprint(filepath.Join(w.dir, name))
+	}
+	return w
+}
+
+// start inicia o monitoramento no modo configurado em VOICES_WATCH
+func (w *voiceWatcher) start(mode string) {
+	w.started = true
+	interval := time.Duration(max(w.manager.Config.VoicesPollSeconds, 1)) * time.Second
+	if mode == "poll" {
+		log.Printf("Monitorando %s a cada %v", w.dir, interval)
+		go w.poll(interval)
+		return
+	}
+
+	notify, err := fsnotify.NewWatcher()
+	if err == nil {
+		if err = w.watchDirs(notify); err != nil {
+			notify.Close()
+		}
+	}
+	if err != nil {
+		log.Printf("Aviso: inotify indisponível para %s (%v); monitorando a cada %v", w.dir, err, interval)
+		go w.poll(interval)
+		return
+	}
+	log.Printf("Monitorando %s", w.dir)
+	go w.notify(notify)
+}
+
+// close interrompe o monitoramento
+func (w *voiceWatcher) close() {
+	if !w.started {
+		return
+	}
+	close(w.stop)
+	<-w.done
+}
+
+// watchDirs observa VoicesDir e o diretório de cada voz, já que o inotify
+// não é recursivo
+func (w *voiceWatcher) watchDirs(notify *fsnotify.Watcher) error {
+	if err := notify.Add(w.dir); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			if err := notify.Add(filepath.Join(w.dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *voiceWatcher) notify(notify *fsnotify.Watcher) {
+	defer close(w.done)
+	defer notify.Close()
+
+	var rescan <-chan time.Time
+	for {
+		select {
+		case event, ok := <-notify.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() && filepath.Dir(event.Name) == filepath.Clean(w.dir) {
+					notify.Add(event.Name)
+				}
+			}
+			rescan = time.After(voiceDebounce)
+		case err, ok := <-notify.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("Aviso: erro ao monitorar %s: %v", w.dir, err)
+		case <-rescan:
+			rescan = nil
+			if w.scan() {
+				rescan = time.After(voiceSettleDelay)
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+func (w *voiceWatcher) poll(interval time.Duration) {
+	defer close(w.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.scan()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// scan compara VoicesDir com as vozes registradas e aplica as mudanças
+// confirmadas. Retorna true se alguma mudança aguarda confirmação.
+func (w *voiceWatcher) scan() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		log.Printf("Aviso: erro ao ler %s: %v", w.dir, err)
+		return false
+	}
+
+	current := make(map[string]string)
+	for _, entry := range entries {
+		// Diretórios ocultos são usados para instalações em andamento
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		current[entry.Name()], _ = modelFingerprint(filepath.Join(w.dir, entry.Name()))
+	}
+
+	for name := range w.known {
+		if _, exists := current[name]; !exists {
+			w.remove(name)
+		}
+	}
+	for name := range w.pending {
+		if _, exists := current[name]; !exists {
+			delete(w.pending, name)
+		}
+	}
+
+	for name, version := range current {
+		registered, isKnown := w.known[name]
+		if isKnown && registered == version {
+			delete(w.pending, name)
+			continue
+		}
+		if rejected, ok := w.rejected[name]; ok && rejected == version {
+			continue
+		}
+		if pending, ok := w.pending[name]; !ok || pending != version {
+			w.pending[name] = version
+			continue
+		}
+		delete(w.pending, name)
+		w.apply(name, version, isKnown)
+	}
+	return len(w.pending) > 0
+}
+
+// load registra imediatamente a versão atual de uma voz
+func (w *voiceWatcher) load(name string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	version, _ := modelFingerprint(filepath.Join(w.dir, name))
+	_, isKnown := w.known[name]
+	delete(w.pending, name)
+	return w.apply(name, version, isKnown)
+}
+
+// unload remove imediatamente uma voz
+func (w *voiceWatcher) unload(name string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.pending, name)
+	return w.remove(name)
+}
+
+// apply registra a versão confirmada de uma voz
+func (w *voiceWatcher) apply(name, version string, isKnown bool) error {
+	err := w.loader.LoadVoice(name)
+	if err != nil {
+		// Uma voz registrada cujo modelo foi apagado deixa de existir;
+		// nos demais casos a versão anterior, se houver, continua ativa
+		if isKnown && version == "" {
+			w.remove(name)
+		}
+		w.rejected[name] = version
+		w.manager.emit(VoiceEvent{Type: VoiceRejected, Voice: name, Error: err.Error()})
+		return err
+	}
+	delete(w.rejected, name)
+	w.known[name] = version
+
+	eventType := VoiceAdded
+	if isKnown {
+		eventType = VoiceUpdated
+	}
+	w.manager.emit(VoiceEvent{Type: eventType, Voice: name})
+	return nil
+}
+
+func (w *voiceWatcher) remove(name string) error {
+	delete(w.known, name)
+	delete(w.rejected, name)
+	if err := w.loader.UnloadVoice(name); err != nil {
+		return err
+	}
+	w.manager.emit(VoiceEvent{Type: VoiceRemoved, Voice: name})
+	return nil
+}
@@ -0,0 +1,127 @@
+package wyoming
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// protocolVersion é a versão do protocolo Wyoming informada nos eventos
+const protocolVersion = "1.5.2"
+
+// maxHeaderSize limita o tamanho da linha de cabeçalho de um evento
+const maxHeaderSize = 1 << 20
+
+// maxPayloadSize limita o payload binário aceito, verificado antes da
+// alocação. O serviço de TTS não recebe áudio, então o limite só precisa
+// acomodar eventos de outros serviços que sejam ignorados.
+const maxPayloadSize = 16 << 20
+
+// Event é uma mensagem do protocolo Wyoming: um cabeçalho JSON em uma
+// linha, seguido opcionalmente de dados JSON extras e de um payload binário
+type Event struct {
+	Type    string
+	Data    map[string]interface{}
+	Payload []byte
+}
+
+type eventHeader struct {
+	Type          string                 `json:"type"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+	DataLength    int                    `json:"data_length,omitempty"`
+	PayloadLength int                    `json:"payload_length,omitempty"`
+	Version       string                 `json:"version,omitempty"`
+}
+
+// ReadEvent lê o próximo evento da conexão
+func ReadEvent(r *bufio.Reader) (*Event, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var header eventHeader
+	if err := json.Unmarshal(line, &header); err != nil {
+		return nil, fmt.Errorf("cabeçalho de evento inválido: %v", err)
+	}
+	if header.DataLength < 0 || header.DataLength > maxHeaderSize ||
+		header.PayloadLength < 0 || header.PayloadLength > maxPayloadSize {
+		return nil, fmt.Errorf("tamanho inválido no evento %s", header.Type)
+	}
+
+	event := &Event{Type: header.Type, Data: header.Data}
+	if event.Data == nil {
+		event.Data = make(map[string]interface{})
+	}
+
+	// Nas versões recentes os dados vêm depois do cabeçalho
+	if header.DataLength > 0 {
+		buf := make([]byte, header.DataLength)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		var extra map[string]interface{}
+		if err := json.Unmarshal(buf, &extra); err != nil {
+			return nil, fmt.Errorf("dados inválidos no evento %s: %v", header.Type, err)
+		}
+		for k, v := range extra {
+			event.Data[k] = v
+		}
+	}
+
+	if header.PayloadLength > 0 {
+		event.Payload = make([]byte, header.PayloadLength)
+		if _, err := io.ReadFull(r, event.Payload); err != nil {
+			return nil, err
+		}
+	}
+	return event, nil
+}
+
+// WriteEvent escreve o evento com os dados no próprio cabeçalho, formato
+// aceito por todas as versões do protocolo
+func WriteEvent(w io.Writer, event *Event) error {
+	header, err := json.Marshal(eventHeader{
+		Type:          event.Type,
+		Data:          event.Data,
+		PayloadLength: len(event.Payload),
+		Version:       protocolVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(append(header, '\n')); err != nil {
+		return err
+	}
+	if len(event.Payload) > 0 {
+		if _, err := w.Write(event.Payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readLine(r *bufio.Reader) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := r.ReadLine()
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, chunk...)
+		if len(line) > maxHeaderSize {
+			return nil, fmt.Errorf("cabeçalho de evento excede %d bytes", maxHeaderSize)
+		}
+		if !isPrefix {
+			return line, nil
+		}
+	}
+}
+
+// stringField lê um campo de texto dos dados de um evento
+func stringField(data map[string]interface{}, key string) string {
+	value, _ := data[key].(string)
+	return value
+}
@@ -0,0 +1,313 @@
+package wyoming
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"tts-api/internal/audio/wav"
+	"tts-api/internal/voice"
+)
+
+// samplesPerChunk é a quantidade de amostras por evento audio-chunk
+const samplesPerChunk = 1024
+
+var attribution = map[string]interface{}{
+	"name": "rhasspy",
+	"url":  "https://github.com/rhasspy/piper",
+}
+
+// Server atende clientes Wyoming (como o Home Assistant) como um serviço de
+// TTS, usando as vozes do voice.Manager
+type Server struct {
+	voices  *voice.Manager
+	version string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+}
+
+// NewServer cria o servidor; version é informada na descrição do serviço
+func NewServer(vm *voice.Manager, version string) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Server{
+		voices:  vm,
+		version: version,
+		ctx:     ctx,
+		cancel:  cancel,
+		conns:   make(map[net.Conn]struct{}),
+	}
+}
+
+// ListenAndServe aceita conexões TCP no endereço informado até Close
+func (s *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		go func() {
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				s.mu.Unlock()
+				conn.Close()
+			}()
+			s.serve(conn)
+		}()
+	}
+}
+
+// Close encerra o listener e as conexões abertas
+func (s *Server) Close() error {
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	if s.listener != nil {
+		return s.listener.Close()
+	}
+	return nil
+}
+
+func (s *Server) serve(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+
+	// Os eventos são lidos em paralelo ao atendimento para que a
+	// desconexão do cliente cancele a síntese em andamento
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	events := make(chan *Event)
+	go func() {
+		defer cancel()
+		defer close(events)
+		for {
+			event, err := ReadEvent(r)
+			if err != nil {
+				if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+					log.Printf("Wyoming: conexão %s encerrada: %v", conn.RemoteAddr(), err)
+				}
+				return
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for event := range events {
+		var err error
+		switch event.Type {
+		case "describe":
+			err = WriteEvent(w, &Event{Type: "info", Data: s.info()})
+		case "synthesize":
+			err = s.synthesize(ctx, w, event.Data)
+		case "ping":
+			err = WriteEvent(w, &Event{Type: "pong", Data: map[string]interface{}{"text": stringField(event.Data, "text")}})
+		default:
+			// Eventos de outros serviços (asr, wake, ...) são ignorados
+			continue
+		}
+		if err == nil {
+			err = w.Flush()
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Wyoming: erro ao responder %s: %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+	}
+}
+
+// info descreve o serviço de TTS e as vozes disponíveis
+func (s *Server) info() map[string]interface{} {
+	var voices []map[string]interface{}
+	for _, name := range s.voices.ListVoices() {
+		info, exists := s.voices.Voice(name)
+		if !exists {
+			continue
+		}
+
+		languages := []string{}
+		if info.Language != "" {
+			languages = append(languages, info.Language)
+		}
+		var speakers []map[string]interface{}
+		for _, speaker := range info.Speakers {
+			speakers = append(speakers, map[string]interface{}{"name": speaker.Name})
+		}
+
+		voices = append(voices, map[string]interface{}{
+			"name":        name,
+			"description": name,
+			"attribution": attribution,
+			"installed":   true,
+			"version":     nil,
+			"languages":   languages,
+			"speakers":    speakers,
+		})
+	}
+
+	return map[string]interface{}{
+		"asr":    []interface{}{},
+		"handle": []interface{}{},
+		"intent": []interface{}{},
+		"wake":   []interface{}{},
+		"tts": []interface{}{
+			map[string]interface{}{
+				"name":        "gotts",
+				"description": "GoTTS (Piper)",
+				"attribution": attribution,
+				"installed":   true,
+				"version":     s.version,
+				"voices":      voices,
+
+				"supports_synthesize_streaming": false,
+			},
+		},
+	}
+}
+
+// synthesize gera o áudio do evento synthesize e o envia em
+// audio-start, audio-chunk e audio-stop
+func (s *Server) synthesize(ctx context.Context, w *bufio.Writer, data map[string]interface{}) error {
+	text := strings.TrimSpace(stringField(data, "text"))
+	if text == "" {
+		return writeError(w, "texto não pode estar vazio", "empty-text")
+	}
+	if limit := s.voices.Config.MaxTexto; len(text) > limit {
+		return writeError(w, fmt.Sprintf("o texto enviado excede o limite de %d caracteres", limit), "text-too-long")
+	}
+
+	var name, language, speaker string
+	if v, ok := data["voice"].(map[string]interface{}); ok {
+		name = stringField(v, "name")
+		language = stringField(v, "language")
+		speaker = stringField(v, "speaker")
+	}
+
+	voiceName, ok := s.selectVoice(name, language)
+	if !ok {
+		return writeError(w, fmt.Sprintf("voz %s não encontrada", name), "voice-not-found")
+	}
+
+	wavData, err := s.voices.Synthesize(ctx, voice.Request{Voice: voiceName, Text: text, Speaker: speaker})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return writeError(w, err.Error(), "synthesis-failed")
+	}
+	pcm, err := wav.Decode(wavData)
+	if err != nil {
+		return writeError(w, err.Error(), "synthesis-failed")
+	}
+
+	format := map[string]interface{}{"rate": pcm.SampleRate, "width": 2, "channels": pcm.Channels}
+	if err := WriteEvent(w, &Event{Type: "audio-start", Data: withTimestamp(format, 0)}); err != nil {
+		return err
+	}
+
+	step := samplesPerChunk * pcm.Channels
+	for start := 0; start < len(pcm.Samples); start += step {
+		end := start + step
+		if end > len(pcm.Samples) {
+			end = len(pcm.Samples)
+		}
+		chunk := &wav.PCM{SampleRate: pcm.SampleRate, Channels: pcm.Channels, Samples: pcm.Samples[start:end]}
+		timestamp := millis(start/pcm.Channels, pcm.SampleRate)
+		if err := WriteEvent(w, &Event{Type: "audio-chunk", Data: withTimestamp(format, timestamp), Payload: pcmBytes(chunk)}); err != nil {
+			return err
+		}
+	}
+
+	return WriteEvent(w, &Event{Type: "audio-stop", Data: map[string]interface{}{
+		"timestamp": millis(pcm.Frames(), pcm.SampleRate),
+	}})
+}
+
+// selectVoice escolhe a voz pelo nome ou, na falta dele, pelo idioma.
+// Sem nenhum dos dois é usada a primeira voz disponível.
+func (s *Server) selectVoice(name, language string) (string, bool) {
+	if name != "" {
+		return name, s.voices.HasVoice(name)
+	}
+
+	voices := s.voices.ListVoices()
+	if language != "" {
+		for _, candidate := range voices {
+			if info, _ := s.voices.Voice(candidate); strings.EqualFold(info.Language, language) {
+				return candidate, true
+			}
+		}
+		// Aceita "pt" para vozes "pt_BR"
+		for _, candidate := range voices {
+			if info, _ := s.voices.Voice(candidate); strings.HasPrefix(strings.ToLower(info.Language), strings.ToLower(language)+"_") {
+				return candidate, true
+			}
+		}
+	}
+	if len(voices) == 0 {
+		return "", false
+	}
+	return voices[0], true
+}
+
+func writeError(w *bufio.Writer, text, code string) error {
+	return WriteEvent(w, &Event{Type: "error", Data: map[string]interface{}{"text": text, "code": code}})
+}
+
+func withTimestamp(format map[string]interface{}, timestamp int) map[string]interface{} {
+	data := map[string]interface{}{"timestamp": timestamp}
+	for k, v := range format {
+		data[k] = v
+	}
+	return data
+}
+
+func millis(frames, sampleRate int) int {
+	return frames * 1000 / sampleRate
+}
+
+// pcmBytes serializa as amostras em 16 bits little-endian
+func pcmBytes(p *wav.PCM) []byte {
+	out := make([]byte, 2*len(p.Samples))
+	for i, sample := range p.Samples {
+		out[2*i] = byte(sample)
+		out[2*i+1] = byte(uint16(sample) >> 8)
+	}
+	return out
+}